@@ -50,8 +50,8 @@ func main() {
 			log.Fatalf("构建失败: %v", err)
 		}
 
-		// 复制配置文件
-		err = copyFile("config.yaml", filepath.Join(platOutputDir, "config.yaml"))
+		// 复制示例配置文件
+		err = copyFile("trade2sql.example.yaml", filepath.Join(platOutputDir, "trade2sql.example.yaml"))
 		if err != nil {
 			log.Printf("复制配置文件失败: %v", err)
 		}
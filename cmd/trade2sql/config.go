@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// maskedSecret 输出配置时代替明文密钥
+const maskedSecret = "******"
+
+// runConfig 配置相关命令，show 输出合并命令行参数、环境变量和配置文件后实际生效的配置
+func runConfig(ctx context.Context, args []string) error {
+	fs := newFlagSet("config show", "[参数]")
+	dbArgs := addDBFlags(fs)
+	showSecrets := fs.Bool("show-secrets", false, "输出明文的密码和连接字符串")
+	if len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		// trade2sql help config 等只查看用法
+		fs.Parse(args)
+	}
+	if len(args) == 0 || args[0] != "show" {
+		return usageError("用法: trade2sql config show [-config 路径] [-profile 连接名] [-show-secrets]")
+	}
+	fs.Parse(args[1:])
+
+	cfg, path, err := dbArgs.load()
+	if err != nil {
+		return err
+	}
+
+	effective := &config.Config{
+		Database:  cfg.Database,
+		Generator: cfg.Generator,
+	}
+	if !*showSecrets {
+		maskSecrets(&effective.Database)
+	}
+
+	data, err := config.Marshal(effective)
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		path = "未找到，使用默认配置"
+	}
+	profile := dbArgs.profileName()
+	if profile == "" {
+		profile = cfg.DefaultProfile
+	}
+	if profile == "" {
+		profile = "未使用命名连接"
+	}
+	fmt.Printf("# 配置文件: %s\n# 连接: %s\n%s", path, profile, data)
+	return nil
+}
+
+// maskSecrets 隐藏配置中的明文密码，env: 等引用原样输出
+// SQLite的连接字符串是文件路径，不包含密钥
+func maskSecrets(cfg *config.DatabaseConfig) {
+	if cfg.Password != "" && !config.IsSecretRef(cfg.Password) {
+		cfg.Password = maskedSecret
+	}
+
+	if cfg.Connection == "" || config.IsSecretRef(cfg.Connection) {
+		return
+	}
+	if dialect, err := db.LookupDialect(cfg.Type); err == nil && dialect.Name() == "sqlite3" {
+		return
+	}
+	cfg.Connection = maskedSecret
+}
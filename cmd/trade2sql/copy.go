@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/copier"
+)
+
+// runCopy 在两个数据库之间复制表数据
+func runCopy(ctx context.Context, args []string) error {
+	fs := newFlagSet("copy", "[参数]")
+	configPath := fs.String("config", "", "配置文件路径，使用命名连接时从中读取")
+	fromProfile := fs.String("from-profile", "", "源库使用配置文件中的命名连接")
+	fromType := fs.String("from-db", "", "源数据库类型 "+dialectList())
+	fromConn := fs.String("from-conn", "", "源数据库连接字符串")
+	fromSchema := fs.String("from-schema", "", "源库的PostgreSQL或SQL Server模式")
+	toProfile := fs.String("to-profile", "", "目标库使用配置文件中的命名连接")
+	toType := fs.String("to-db", "", "目标数据库类型 "+dialectList())
+	toConn := fs.String("to-conn", "", "目标数据库连接字符串")
+	toSchema := fs.String("to-schema", "", "目标库的PostgreSQL或SQL Server模式，新建的表位于该模式中")
+	tables := fs.String("tables", "", "要复制的表，逗号分隔，默认全部表")
+	batch := fs.Int("batch", copier.DefaultBatchSize, "每批写入的行数")
+	limit := fs.Int("limit", 0, "每个表最多复制的行数，0表示不限制")
+	progressFile := fs.String("progress", "", "进度文件路径，再次执行时从中断处继续")
+	where := mapFlag{}
+	fs.Var(where, "where", "表的过滤条件，格式 表名=条件，可重复指定")
+	fs.Parse(args)
+
+	srcCfg, err := copyDatabaseConfig(*configPath, "from", *fromProfile, *fromType, *fromConn, *fromSchema)
+	if err != nil {
+		return err
+	}
+	dstCfg, err := copyDatabaseConfig(*configPath, "to", *toProfile, *toType, *toConn, *toSchema)
+	if err != nil {
+		return err
+	}
+
+	src, err := srcCfg.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("连接源数据库失败: %v", err)
+	}
+	defer src.Close()
+
+	dst, err := dstCfg.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("连接目标数据库失败: %v", err)
+	}
+	defer dst.Close()
+
+	c := copier.New(src, dst, copier.Options{
+		Tables:       splitList(*tables),
+		BatchSize:    *batch,
+		Limit:        *limit,
+		Where:        where,
+		ProgressFile: *progressFile,
+		Logf: func(format string, args ...interface{}) {
+			fmt.Printf(format, args...)
+		},
+	})
+
+	err = c.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("数据复制完成")
+	return nil
+}
+
+// copyDatabaseConfig 确定复制一端的数据库配置，prefix为参数前缀 from 或 to，configPath为空时自动查找配置文件
+// 指定了连接名时从配置文件读取该连接，命令行中的类型、连接字符串和模式优先
+func copyDatabaseConfig(configPath, prefix, profile, dbType, conn, schema string) (config.DatabaseConfig, error) {
+	var cfg config.DatabaseConfig
+	if profile != "" {
+		c, _, err := config.Discover(configPath)
+		if err != nil {
+			return cfg, err
+		}
+		cfg, _, err = c.Select(profile)
+		if err != nil {
+			return cfg, err
+		}
+	} else if dbType == "" || conn == "" {
+		return cfg, fmt.Errorf("必须指定 -%[1]s-profile，或同时指定 -%[1]s-db 和 -%[1]s-conn", prefix)
+	}
+
+	if dbType != "" {
+		cfg.Type = dbType
+	}
+	if conn != "" {
+		cfg.Connection = conn
+	}
+	if schema != "" {
+		cfg.Schema = schema
+	}
+	return cfg, nil
+}
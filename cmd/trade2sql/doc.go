@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/trade2sql/internal/db"
+	"github.com/trade2sql/internal/docgen"
+)
+
+// runDoc 输出Markdown或HTML格式的数据字典
+func runDoc(ctx context.Context, args []string) error {
+	fs := newFlagSet("doc", "[参数]")
+	dbArgs := addDBFlags(fs)
+	format := fs.String("format", string(docgen.FormatMarkdown), "文档格式 (markdown, html)")
+	title := fs.String("title", "", "文档标题，默认为\"数据字典\"")
+	include := fs.String("include", "", "包含的表，逗号分隔，支持通配符")
+	exclude := fs.String("exclude", "", "排除的表，逗号分隔，支持通配符")
+	output := fs.String("output", "", "输出文件路径，默认输出到标准输出")
+	workers := fs.Int("workers", db.DefaultWorkers, "并发读取表结构的协程数")
+	fs.Parse(args)
+
+	cfg, _, err := dbArgs.load()
+	if err != nil {
+		return err
+	}
+
+	database, err := cfg.Database.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("连接数据库失败: %v", err)
+	}
+	defer database.Close()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return docgen.Generate(ctx, database, w, docgen.Options{
+		Format:  docgen.Format(*format),
+		Title:   *title,
+		Include: splitList(*include),
+		Exclude: splitList(*exclude),
+		Workers: *workers,
+	})
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/trade2sql/internal/db"
+	"github.com/trade2sql/internal/erd"
+)
+
+// runERD 输出Mermaid、PlantUML或Graphviz格式的ER图
+func runERD(ctx context.Context, args []string) error {
+	fs := newFlagSet("erd", "[参数]")
+	dbArgs := addDBFlags(fs)
+	format := fs.String("format", string(erd.FormatMermaid), "图表格式 (mermaid, plantuml, dot)")
+	include := fs.String("include", "", "包含的表，逗号分隔，支持通配符")
+	exclude := fs.String("exclude", "", "排除的表，逗号分隔，支持通配符")
+	allColumns := fs.Bool("all-columns", false, "输出全部列，默认只输出主键和外键列")
+	output := fs.String("output", "", "输出文件路径，默认输出到标准输出")
+	workers := fs.Int("workers", db.DefaultWorkers, "并发读取表结构的协程数")
+	fs.Parse(args)
+
+	cfg, _, err := dbArgs.load()
+	if err != nil {
+		return err
+	}
+
+	database, err := cfg.Database.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("连接数据库失败: %v", err)
+	}
+	defer database.Close()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return erd.Generate(ctx, database, w, erd.Options{
+		Format:     erd.Format(*format),
+		Include:    splitList(*include),
+		Exclude:    splitList(*exclude),
+		Workers:    *workers,
+		AllColumns: *allColumns,
+	})
+}
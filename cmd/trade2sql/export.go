@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/trade2sql/internal/exporter"
+)
+
+// runExport 导出表数据为INSERT语句、CSV、JSON Lines或Go测试数据
+func runExport(ctx context.Context, args []string) error {
+	fs := newFlagSet("export", "[参数]")
+	dbArgs := addDBFlags(fs)
+	table := fs.String("table", "", "表名")
+	format := fs.String("format", string(exporter.FormatInsert), "导出格式 (insert, csv, jsonl, go)")
+	where := fs.String("where", "", "过滤条件")
+	limit := fs.Int("limit", 0, "最多导出的行数，0表示不限制")
+	dialect := fs.String("dialect", "", "INSERT语句的目标方言 "+dialectList()+"，默认与源库相同")
+	packageName := fs.String("package", "", "Go测试数据的包名，默认使用配置中的包名")
+	output := fs.String("output", "", "输出文件路径，默认输出到标准输出")
+	fs.Parse(args)
+
+	if *table == "" {
+		return usageError("请指定表名 (-table)")
+	}
+
+	cfg, _, err := dbArgs.load()
+	if err != nil {
+		return err
+	}
+	if *packageName != "" {
+		cfg.Generator.PackageName = *packageName
+	}
+
+	database, err := cfg.Database.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("连接数据库失败: %v", err)
+	}
+	defer database.Close()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return exporter.Export(ctx, database, w, exporter.Options{
+		Table:     *table,
+		Where:     *where,
+		Limit:     *limit,
+		Format:    exporter.Format(*format),
+		Dialect:   *dialect,
+		Generator: cfg.Generator,
+	})
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// dbFlags 各命令共用的数据库连接参数
+type dbFlags struct {
+	config  *string
+	profile *string
+	dbType  *string
+	conn    *string
+	schema  *string
+
+	connectTimeout *int
+	queryTimeout   *int
+}
+
+// addDBFlags 注册数据库连接参数
+func addDBFlags(fs *flag.FlagSet) *dbFlags {
+	return &dbFlags{
+		config:  fs.String("config", "", "配置文件路径，默认依次查找 $"+config.EnvConfig+"、./"+config.FileName+"、./config.yaml 和用户配置目录"),
+		profile: fs.String("profile", "", "使用配置文件中的命名连接，默认使用 $"+config.EnvProfile+" 或 default_profile"),
+		dbType:  fs.String("db", "", "数据库类型 "+dialectList()),
+		conn:    fs.String("conn", "", "数据库连接字符串，覆盖配置文件中的连接参数"),
+		schema:  fs.String("schema", "", "PostgreSQL或SQL Server模式，为空时列出全部模式的表"),
+
+		connectTimeout: fs.Int("connect-timeout", 0, "连接超时秒数，默认10秒"),
+		queryTimeout:   fs.Int("query-timeout", 0, "读取表结构的查询超时秒数，默认不限制"),
+	}
+}
+
+// dialectList 返回已注册的数据库类型列表，用于参数说明
+func dialectList() string {
+	return "(" + strings.Join(db.DialectNames(), ", ") + ")"
+}
+
+// load 加载配置并应用所选的连接，返回配置和配置文件路径
+// 优先级从高到低依次为命令行参数、TRADE2SQL_* 环境变量、配置文件、默认配置
+func (f *dbFlags) load() (*config.Config, string, error) {
+	cfg, path, err := config.Discover(*f.config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = cfg.Use(f.profileName())
+	if err != nil {
+		return nil, path, err
+	}
+
+	err = config.ApplyEnv(&cfg.Database)
+	if err != nil {
+		return nil, path, err
+	}
+
+	f.apply(&cfg.Database)
+	return cfg, path, nil
+}
+
+// profileName 返回命令行或环境变量指定的连接名，都未指定时返回空字符串
+func (f *dbFlags) profileName() string {
+	if *f.profile != "" {
+		return *f.profile
+	}
+	return os.Getenv(config.EnvProfile)
+}
+
+// apply 用命令行参数覆盖配置
+func (f *dbFlags) apply(cfg *config.DatabaseConfig) {
+	if *f.dbType != "" {
+		cfg.Type = *f.dbType
+	}
+	if *f.conn != "" {
+		cfg.Connection = *f.conn
+	}
+	if *f.schema != "" {
+		cfg.Schema = *f.schema
+	}
+	if *f.connectTimeout > 0 {
+		cfg.ConnectTimeout = *f.connectTimeout
+	}
+	if *f.queryTimeout > 0 {
+		cfg.QueryTimeout = *f.queryTimeout
+	}
+}
+
+// mapFlag 可重复指定的 key=value 参数
+type mapFlag map[string]string
+
+// String 实现 flag.Value
+func (m mapFlag) String() string {
+	var pairs []string
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set 实现 flag.Value
+func (m mapFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("格式应为 key=value: %s", value)
+	}
+	m[k] = v
+	return nil
+}
+
+// splitList 拆分逗号分隔的列表，忽略空项
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// containsString 检查切片中是否包含指定字符串
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
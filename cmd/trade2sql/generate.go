@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+	"github.com/trade2sql/internal/generator"
+)
+
+// genFlags generate 和 diff 命令共用的参数
+type genFlags struct {
+	db      *dbFlags
+	table   *string
+	output  *string
+	workers *int
+	layout  *string
+}
+
+// addGenFlags 注册生成结构体的参数
+func addGenFlags(fs *flag.FlagSet) *genFlags {
+	return &genFlags{
+		db:      addDBFlags(fs),
+		table:   fs.String("table", "", "表名，多个表用逗号分隔，支持通配符，如 user_*，也可以写在参数之后"),
+		output:  fs.String("output", "models", "输出目录，生成单张表时也可以是 .go 文件路径"),
+		workers: fs.Int("workers", db.DefaultWorkers, "生成多个表时并发读取表结构的协程数"),
+		layout:  fs.String("layout", "", "输出布局 "+strings.Join(config.Layouts, "、")+"，覆盖配置文件中的 generator.layout"),
+	}
+}
+
+// runGenerate 生成表对应的Go结构体
+func runGenerate(ctx context.Context, args []string) error {
+	fs := newFlagSet("generate", "[参数] [表名...]")
+	gf := addGenFlags(fs)
+	force := fs.Bool("force", false, "覆盖不是 trade2sql 生成的已有文件")
+	dryRun := fs.Bool("dry-run", false, "只列出将新建、修改和未变的文件，不写入文件")
+	showDiff := fs.Bool("diff", false, "显示已有文件与生成结果的差异，不写入文件")
+	fs.Parse(args)
+
+	g, err := gf.open(ctx, fs.Args())
+	if err != nil {
+		return err
+	}
+	defer g.database.Close()
+
+	if *dryRun || *showDiff {
+		return g.preview(ctx, *showDiff)
+	}
+	return g.write(ctx, *force)
+}
+
+// runDiff 显示生成结果与已有文件的差异，有差异时以 exitChanged 退出
+func runDiff(ctx context.Context, args []string) error {
+	fs := newFlagSet("diff", "[参数] [表名...]")
+	gf := addGenFlags(fs)
+	fs.Parse(args)
+
+	g, err := gf.open(ctx, fs.Args())
+	if err != nil {
+		return err
+	}
+	defer g.database.Close()
+
+	return g.preview(ctx, true)
+}
+
+// generation 一次生成的数据库连接和参数
+type generation struct {
+	database *db.Database
+	cfg      config.GeneratorConfig
+	names    []string // 表名或通配符
+	batch    bool     // 是否批量生成到输出目录
+	output   string
+	workers  int
+}
+
+// open 加载配置并连接数据库，args为命令后的表名
+func (f *genFlags) open(ctx context.Context, args []string) (*generation, error) {
+	names := append(splitList(*f.table), args...)
+	if len(names) == 0 {
+		return nil, usageError("请指定表名 (-table 或命令后的表名)")
+	}
+	if *f.layout != "" && !containsString(config.Layouts, *f.layout) {
+		return nil, usageError(fmt.Sprintf("未知的输出布局 %s，可用的布局: %s", *f.layout, strings.Join(config.Layouts, ", ")))
+	}
+
+	// 加载配置，应用所选的连接并用命令行参数覆盖
+	cfg, _, err := f.db.load()
+	if err != nil {
+		return nil, err
+	}
+	if *f.layout != "" {
+		cfg.Generator.Layout = *f.layout
+	}
+	if cfg.Generator.SoftDelete != nil && *cfg.Generator.SoftDelete != "" && !cfg.Generator.HasTag("gorm") {
+		fmt.Fprintf(os.Stderr, "提示: 标签格式 %s 不包含 gorm，soft_delete 设置的软删除列 %s 不会生成为 gorm.DeletedAt\n", cfg.Generator.TagFormat, *cfg.Generator.SoftDelete)
+	}
+
+	database, err := cfg.Database.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("连接数据库失败: %v", err)
+	}
+
+	return &generation{
+		database: database,
+		cfg:      cfg.Generator,
+		names:    names,
+		batch:    len(names) > 1 || strings.ContainsAny(names[0], "*?["),
+		output:   *f.output,
+		workers:  *f.workers,
+	}, nil
+}
+
+// outputPath 返回单张表的输出文件路径，输出不是 .go 文件时视为目录
+func (g *generation) outputPath() string {
+	if strings.HasSuffix(g.output, ".go") {
+		return g.output
+	}
+	return filepath.Join(g.output, generator.OutputFileName(g.database, g.names[0], g.cfg))
+}
+
+// write 生成结构体并写入文件
+func (g *generation) write(ctx context.Context, force bool) error {
+	if g.batch {
+		return generateTables(ctx, g.database, g.names, g.output, g.cfg, g.workers, force)
+	}
+
+	outputPath := g.outputPath()
+	err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	err = generator.GenerateStruct(ctx, g.database, g.names[0], outputPath, g.cfg, force)
+	if err != nil {
+		return fmt.Errorf("生成结构体失败: %v", err)
+	}
+	fmt.Printf("已成功生成结构体到 %s\n", outputPath)
+	return nil
+}
+
+// preview 比较生成结果与已有文件，不写入文件，有变化时返回 errChanged
+func (g *generation) preview(ctx context.Context, showDiff bool) error {
+	var files map[string]string
+	var err error
+	if g.batch {
+		files, err = batchFiles(ctx, g.database, g.names, g.output, g.cfg, g.workers)
+	} else {
+		files, err = generator.StructFiles(ctx, g.database, g.names[0], g.outputPath(), g.cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("生成结构体失败: %v", err)
+	}
+
+	changed, err := previewChanges(files, showDiff)
+	if err != nil {
+		return err
+	}
+	if changed {
+		return errChanged
+	}
+	return nil
+}
+
+// generateTables 批量生成匹配的表的结构体到输出目录
+func generateTables(ctx context.Context, database *db.Database, patterns []string, dir string, cfg config.GeneratorConfig, workers int, force bool) error {
+	names, err := matchTables(ctx, database, patterns)
+	if err != nil {
+		return err
+	}
+
+	paths, err := generator.GenerateStructs(ctx, database, names, dir, cfg, workers, force)
+	if err != nil {
+		return fmt.Errorf("生成结构体失败: %v", err)
+	}
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	fmt.Printf("已成功生成 %d 个表的结构体到 %s\n", len(names), dir)
+	return nil
+}
+
+// matchTables 返回匹配的表名，没有匹配的表时返回错误
+func matchTables(ctx context.Context, database *db.Database, patterns []string) ([]string, error) {
+	all, err := database.GetTableList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取表列表失败: %v", err)
+	}
+
+	names, err := db.FilterTables(all, patterns, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("没有匹配 %s 的表", strings.Join(patterns, ","))
+	}
+	return names, nil
+}
+
+// batchFiles 生成匹配的表的结构体，返回输出目录下的文件路径到内容的映射，不写入文件
+func batchFiles(ctx context.Context, database *db.Database, patterns []string, dir string, cfg config.GeneratorConfig, workers int) (map[string]string, error) {
+	names, err := matchTables(ctx, database, patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := generator.GenerateFiles(ctx, database, names, cfg, workers)
+	if err != nil {
+		return nil, err
+	}
+	paths := map[string]string{}
+	for name, content := range files {
+		paths[filepath.Join(dir, name)] = content
+	}
+	return paths, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// starterFields 初始配置中各连接参数的示例
+var starterFields = map[string]string{
+	db.FieldHost:     "  host: localhost\n",
+	db.FieldPort:     "  port: 0 # 0表示使用数据库的默认端口\n",
+	db.FieldUser:     "  user: app\n",
+	db.FieldPassword: "  # 密码从环境变量读取，也可以写作 file:/path/to/secret 从文件读取\n  password: env:TRADE2SQL_DB_PASSWORD\n",
+	db.FieldDatabase: "  database: app\n",
+	db.FieldSSLMode:  "  sslmode: disable\n",
+	db.FieldSocket:   "  # socket: /var/run/mysqld/mysqld.sock # 设置后忽略主机和端口\n",
+	db.FieldFile:     "  file: app.db\n",
+	db.FieldParams:   "  # params: # 附加的驱动参数\n  #   key: value\n",
+}
+
+// runInit 在当前目录创建初始配置文件
+func runInit(ctx context.Context, args []string) error {
+	fs := newFlagSet("init", "[参数]")
+	dbType := fs.String("db", "mysql", "数据库类型 "+dialectList())
+	output := fs.String("output", config.FileName, "配置文件路径")
+	force := fs.Bool("force", false, "覆盖已有的配置文件")
+	fs.Parse(args)
+
+	dialect, err := db.LookupDialect(*dbType)
+	if err != nil {
+		return usageError(err.Error())
+	}
+
+	if _, err := os.Stat(*output); err == nil && !*force {
+		return fmt.Errorf("配置文件 %s 已存在，使用 -force 覆盖", *output)
+	}
+
+	err = os.WriteFile(*output, []byte(starterConfig(dialect)), 0644)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("已创建配置文件 %s\n", *output)
+	return nil
+}
+
+// starterConfig 返回指定数据库类型的初始配置
+func starterConfig(dialect db.Dialect) string {
+	var b strings.Builder
+	b.WriteString("# trade2sql 配置文件，全部配置项见 trade2sql.example.yaml\n")
+	b.WriteString("database:\n")
+	b.WriteString("  type: " + dialect.Name() + "\n")
+	for _, field := range dialect.ConnFields() {
+		b.WriteString(starterFields[field])
+	}
+
+	defaults := config.Default().Generator
+	b.WriteString("generator:\n")
+	b.WriteString("  package_name: " + defaults.PackageName + "\n")
+	b.WriteString("  tag_format: " + defaults.TagFormat + "\n")
+	b.WriteString("  # 输出布局 " + strings.Join(config.Layouts, "、") + "\n")
+	b.WriteString("  layout: " + config.LayoutPerTable + "\n")
+	return b.String()
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// 列表和列信息的输出格式
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// tableJSON list 命令以JSON格式输出的表信息
+type tableJSON struct {
+	Schema  string `json:"schema,omitempty"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// columnJSON describe 命令以JSON格式输出的列信息
+type columnJSON struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Nullable bool    `json:"nullable"`
+	Primary  bool    `json:"primary"`
+	Default  *string `json:"default"`
+	Comment  string  `json:"comment,omitempty"`
+}
+
+// runList 列出数据库中的表和视图
+func runList(ctx context.Context, args []string) error {
+	fs := newFlagSet("list", "[参数]")
+	dbArgs := addDBFlags(fs)
+	include := fs.String("include", "", "包含的表，逗号分隔，支持通配符")
+	exclude := fs.String("exclude", "", "排除的表，逗号分隔，支持通配符")
+	format := fs.String("format", formatText, "输出格式 (text, json)")
+	fs.Parse(args)
+
+	if *format != formatText && *format != formatJSON {
+		return usageError(fmt.Sprintf("不支持的输出格式: %s", *format))
+	}
+
+	cfg, _, err := dbArgs.load()
+	if err != nil {
+		return err
+	}
+
+	database, err := cfg.Database.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("连接数据库失败: %v", err)
+	}
+	defer database.Close()
+
+	tables, err := database.GetTables(ctx)
+	if err != nil {
+		return fmt.Errorf("获取表列表失败: %v", err)
+	}
+
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.QualifiedName()
+	}
+	names, err = db.FilterTables(names, splitList(*include), splitList(*exclude))
+	if err != nil {
+		return err
+	}
+
+	result := []tableJSON{}
+	for _, table := range tables {
+		if !containsString(names, table.QualifiedName()) {
+			continue
+		}
+		result = append(result, tableJSON{
+			Schema:  table.Schema,
+			Name:    table.Name,
+			Type:    string(table.Type),
+			Comment: table.Comment,
+		})
+	}
+
+	if *format == formatJSON {
+		return printJSON(result)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "名称\t类型\t注释")
+	for _, table := range result {
+		name := table.Name
+		if table.Schema != "" {
+			name = table.Schema + "." + name
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, table.Type, oneLine(table.Comment))
+	}
+	return w.Flush()
+}
+
+// runDescribe 显示表的列信息
+func runDescribe(ctx context.Context, args []string) error {
+	fs := newFlagSet("describe", "[参数] <表名>")
+	dbArgs := addDBFlags(fs)
+	format := fs.String("format", formatText, "输出格式 (text, json)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usageError("请指定一个表名")
+	}
+	if *format != formatText && *format != formatJSON {
+		return usageError(fmt.Sprintf("不支持的输出格式: %s", *format))
+	}
+	tableName := fs.Arg(0)
+
+	cfg, _, err := dbArgs.load()
+	if err != nil {
+		return err
+	}
+
+	database, err := cfg.Database.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("连接数据库失败: %v", err)
+	}
+	defer database.Close()
+
+	columns, err := database.GetTableInfo(ctx, tableName)
+	if err != nil {
+		return fmt.Errorf("获取表 %s 结构失败: %v", tableName, err)
+	}
+
+	if *format == formatJSON {
+		result := make([]columnJSON, len(columns))
+		for i, col := range columns {
+			result[i] = columnJSON{
+				Name:     col.Name,
+				Type:     col.Type,
+				Nullable: col.IsNullable,
+				Primary:  col.IsPrimary,
+				Default:  col.Default,
+				Comment:  col.Comment,
+			}
+		}
+		return printJSON(result)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "列名\t类型\t可空\t主键\t默认值\t注释")
+	for _, col := range columns {
+		def := ""
+		if col.Default != nil {
+			def = *col.Default
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", col.Name, col.Type, yesNo(col.IsNullable), yesNo(col.IsPrimary), oneLine(def), oneLine(col.Comment))
+	}
+	return w.Flush()
+}
+
+// printJSON 以缩进的JSON格式输出到标准输出
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// yesNo 将布尔值显示为 是/否
+func yesNo(b bool) string {
+	if b {
+		return "是"
+	}
+	return "否"
+}
+
+// oneLine 将多行文本合并为一行，避免打乱表格
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -1,78 +1,217 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"io"
 	"os"
-
-	_ "embed"
+	"os/signal"
+	"runtime"
+	"strings"
 
 	"github.com/trade2sql/internal/config"
-	"github.com/trade2sql/internal/db"
-	"github.com/trade2sql/internal/generator"
 	"github.com/trade2sql/internal/gui"
+	"github.com/trade2sql/pkg/build"
+)
+
+// 退出码
+const (
+	exitOK      = 0
+	exitError   = 1 // 命令执行失败
+	exitUsage   = 2 // 命令或参数错误，与 flag 包解析参数失败时相同
+	exitChanged = 3 // 生成结果与已有文件不同，见 generate -dry-run、-diff 和 diff 命令
 )
 
-//go:embed config.yaml
-var configFile string
+// errChanged 生成结果与已有文件不同，以 exitChanged 退出
+var errChanged = errors.New("生成结果与已有文件不同")
+
+// usageError 命令参数错误，以 exitUsage 退出
+type usageError string
+
+// Error 实现 error 接口
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// command 子命令
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+// commands 子命令，通过 trade2sql <命令> [参数] 调用，按帮助中的顺序排列
+// 子命令的帮助会引用该列表，因此在 init 中赋值
+var commands []command
+
+func init() {
+	commands = []command{
+		{"list", "列出数据库中的表和视图", runList},
+		{"describe", "显示表的列信息", runDescribe},
+		{"generate", "生成表对应的Go结构体", runGenerate},
+		{"diff", "显示生成结果与已有文件的差异，不写入文件", runDiff},
+		{"init", "在当前目录创建配置文件", runInit},
+		{"config", "显示实际生效的配置", runConfig},
+		{"copy", "在两个数据库之间复制表数据", runCopy},
+		{"export", "导出表数据", runExport},
+		{"erd", "输出ER图", runERD},
+		{"doc", "输出数据字典", runDoc},
+		{"gui", "启动图形界面", runGUI},
+		{"version", "显示版本信息", runVersion},
+	}
+}
 
 func main() {
-	// 命令行参数
-	// configFile := flag.String("config", "config.yaml", "配置文件路径")
-	dbType := flag.String("db", "", "数据库类型 (mysql, postgres, sqlite)")
-	dbConn := flag.String("conn", "", "数据库连接字符串")
-	table := flag.String("table", "", "表名")
-	output := flag.String("output", "models", "输出文件路径")
-	guiMode := flag.Bool("gui", true, "启动GUI模式")
-	flag.Parse()
-
-	// GUI模式
-	if *guiMode {
-		gui.StartGUI()
-		return
+	// 按 Ctrl+C 时取消正在进行的连接和查询
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:])
+	stop()
+	os.Exit(code)
+}
+
+// run 执行命令并返回退出码，没有参数时启动图形界面
+func run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		return exitCode("gui", runGUI(ctx, nil))
 	}
 
-	// 命令行模式
-	// 加载配置
-	cfg, err := config.Load(configFile)
-	if err != nil {
-		// 如果配置文件不存在，使用默认配置
-		if os.IsNotExist(err) {
-			cfg = config.Default()
-		} else {
-			log.Fatalf("加载配置文件失败: %v", err)
+	name, args := args[0], args[1:]
+	switch {
+	case name == "help" || name == "-h" || name == "-help" || name == "--help":
+		return runHelp(ctx, args)
+	case strings.HasPrefix(name, "-"):
+		// 旧版本不带命令、直接使用参数生成结构体
+		args = legacyArgs(append([]string{name}, args...))
+		if len(args) == 0 {
+			return exitCode("gui", runGUI(ctx, nil))
 		}
+		fmt.Fprintln(os.Stderr, "提示: 不带命令的用法已废弃，请使用 trade2sql generate [参数]")
+		name = "generate"
 	}
 
-	// 命令行参数覆盖配置文件
-	if *dbType != "" {
-		cfg.Database.Type = *dbType
+	cmd, ok := lookupCommand(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "未知的命令: %s\n\n", name)
+		printUsage(os.Stderr)
+		return exitUsage
 	}
-	if *dbConn != "" {
-		cfg.Database.Connection = *dbConn
+	return exitCode(name, cmd.run(ctx, args))
+}
+
+// exitCode 输出命令的错误并返回对应的退出码
+func exitCode(name string, err error) int {
+	var usage usageError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errChanged):
+		return exitChanged
+	case errors.As(err, &usage):
+		fmt.Fprintf(os.Stderr, "%v\n运行 trade2sql help %s 查看用法\n", err, name)
+		return exitUsage
+	default:
+		fmt.Fprintf(os.Stderr, "%s 执行失败: %v\n", name, err)
+		return exitError
 	}
+}
 
-	// 连接数据库
-	database, err := db.Connect(cfg.Database.Type, cfg.Database.Connection)
-	if err != nil {
-		log.Fatalf("连接数据库失败: %v", err)
+// legacyArgs 去掉旧版本的 -gui 参数，其余参数与 generate 命令相同
+func legacyArgs(args []string) []string {
+	var result []string
+	for _, arg := range args {
+		flagName, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if flagName == "gui" {
+			continue
+		}
+		result = append(result, arg)
 	}
-	defer database.Close()
+	return result
+}
 
-	// 生成结构体
-	if *table != "" {
-		outputPath := *output
-		if outputPath == "" {
-			outputPath = fmt.Sprintf("%s_model.go", *table)
+// lookupCommand 按名称查找子命令
+func lookupCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
 		}
+	}
+	return command{}, false
+}
+
+// printUsage 输出全部命令的说明
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "用法: trade2sql <命令> [参数]")
+	fmt.Fprintln(w, "\n命令:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\n不带命令时启动图形界面，运行 trade2sql help <命令> 查看命令的参数")
+	fmt.Fprintf(w, "\n退出码: %d 成功，%d 执行失败，%d 参数错误，%d 生成结果与已有文件不同\n", exitOK, exitError, exitUsage, exitChanged)
+}
+
+// runHelp 输出命令列表或指定命令的参数说明
+func runHelp(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stdout)
+		return exitOK
+	}
 
-		err = generator.GenerateStruct(database, *table, outputPath, cfg.Generator)
-		if err != nil {
-			log.Fatalf("生成结构体失败: %v", err)
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "未知的命令: %s\n\n", args[0])
+		printUsage(os.Stderr)
+		return exitUsage
+	}
+	// 各命令解析到 -h 时输出参数说明后退出
+	return exitCode(cmd.name, cmd.run(ctx, []string{"-h"}))
+}
+
+// newFlagSet 创建子命令的参数集，usage为命令名之后的参数格式
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "用法: trade2sql %s %s\n", name, usage)
+		if cmd, ok := lookupCommand(strings.Fields(name)[0]); ok {
+			fmt.Fprintf(out, "\n%s\n", cmd.summary)
 		}
-		fmt.Printf("已成功生成结构体到 %s\n", outputPath)
-	} else {
-		fmt.Println("请指定表名 (-table)")
+		fmt.Fprintln(out, "\n参数:")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// runGUI 启动图形界面
+func runGUI(ctx context.Context, args []string) error {
+	fs := newFlagSet("gui", "[-config 路径]")
+	configPath := fs.String("config", "", "配置文件路径，默认依次查找 $"+config.EnvConfig+"、./"+config.FileName+"、./config.yaml 和用户配置目录")
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		return usageError(fmt.Sprintf("多余的参数: %s", strings.Join(fs.Args(), " ")))
+	}
+
+	gui.StartGUI(*configPath)
+	return nil
+}
+
+// runVersion 输出版本、提交和构建时间
+func runVersion(ctx context.Context, args []string) error {
+	fs := newFlagSet("version", "")
+	fs.Parse(args)
+
+	info := build.Info
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	fmt.Printf("trade2sql %s\n", info.Version)
+	if info.Commit != "" {
+		fmt.Printf("提交: %s\n", info.Commit)
+	}
+	if info.BuildTime != "" {
+		fmt.Printf("构建时间: %s\n", info.BuildTime)
 	}
+	fmt.Printf("Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	return nil
 }
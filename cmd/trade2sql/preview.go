@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/trade2sql/internal/diff"
+	"github.com/trade2sql/internal/generator"
+)
+
+// previewChanges 列出生成的文件相对已有文件的变化，showDiff为true时同时显示差异
+// 返回是否有文件将被新建或修改
+func previewChanges(files map[string]string, showDiff bool) (bool, error) {
+	changes, err := generator.Plan(files)
+	if err != nil {
+		return false, err
+	}
+
+	counts := map[generator.ChangeStatus]int{}
+	for _, change := range changes {
+		counts[change.Status]++
+
+		note := ""
+		if change.Protected && change.Status == generator.Modified {
+			note = " (不是 trade2sql 生成的文件，需要 -force 才会覆盖)"
+		}
+		fmt.Printf("%s %s%s\n", change.Status, change.Path, note)
+
+		if showDiff && change.Status != generator.Unchanged {
+			oldName := change.Path
+			if change.Status == generator.Created {
+				oldName = ""
+			}
+			fmt.Print(diff.Unified(oldName, change.Path, change.Old, change.New))
+		}
+	}
+
+	fmt.Printf("共 %d 个文件: 新建 %d，修改 %d，未变 %d\n", len(changes),
+		counts[generator.Created], counts[generator.Modified], counts[generator.Unchanged])
+	return counts[generator.Created]+counts[generator.Modified] > 0, nil
+}
@@ -1,48 +1,227 @@
 package config
 
 import (
+	"context"
 	"io/ioutil"
+	"strings"
+	"time"
 
+	"github.com/trade2sql/internal/db"
 	"gopkg.in/yaml.v2"
 )
 
 // Config 应用配置
+// 配置了 profiles 时可以按名称选择连接，未选择连接时使用 default_profile，再没有时使用顶层的 database
 type Config struct {
-	Database  DatabaseConfig  `yaml:"database"`
-	Generator GeneratorConfig `yaml:"generator"`
+	Database       DatabaseConfig     `yaml:"database"`
+	Generator      GeneratorConfig    `yaml:"generator"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`        // 命名的连接配置，如 dev、staging
+	DefaultProfile string             `yaml:"default_profile,omitempty"` // 默认使用的连接名
 }
 
 // DatabaseConfig 数据库配置
+// 连接字符串 Connection 不为空时直接使用，否则由结构化的连接参数按数据库类型生成
 type DatabaseConfig struct {
 	Type       string `yaml:"type"`
-	Connection string `yaml:"connection"`
+	Connection string `yaml:"connection,omitempty"` // 原始连接字符串，设置后覆盖下面的连接参数
+	Schema     string `yaml:"schema,omitempty"`     // PostgreSQL或SQL Server模式，为空时列出全部模式的表
+
+	Host     string            `yaml:"host,omitempty"`
+	Port     int               `yaml:"port,omitempty"` // 0表示使用数据库的默认端口
+	User     string            `yaml:"user,omitempty"`
+	Password string            `yaml:"password,omitempty"`
+	Database string            `yaml:"database,omitempty"`
+	Params   map[string]string `yaml:"params,omitempty"`  // 附加的驱动参数，如 charset: utf8mb4
+	SSLMode  string            `yaml:"sslmode,omitempty"` // PostgreSQL的sslmode
+	Socket   string            `yaml:"socket,omitempty"`  // MySQL的Unix套接字路径
+	File     string            `yaml:"file,omitempty"`    // SQLite数据库文件路径
+
+	ConnectTimeout int `yaml:"connect_timeout,omitempty"` // 连接超时秒数，0表示使用默认的10秒
+	QueryTimeout   int `yaml:"query_timeout,omitempty"`   // 读取表结构的查询超时秒数，0表示不限制
+}
+
+// DSN 返回连接字符串，未设置原始连接字符串时由连接参数生成
+// 字段按原样使用，包含环境变量或密钥引用时应先调用 Resolve
+func (c DatabaseConfig) DSN() (string, error) {
+	if c.Connection != "" {
+		return c.Connection, nil
+	}
+
+	dialect, err := db.LookupDialect(c.Type)
+	if err != nil {
+		return "", err
+	}
+	return dialect.DSN(db.ConnParams{
+		Host:     c.Host,
+		Port:     c.Port,
+		User:     c.User,
+		Password: c.Password,
+		Database: c.Database,
+		Params:   c.Params,
+		SSLMode:  c.SSLMode,
+		Socket:   c.Socket,
+		File:     c.File,
+	})
+}
+
+// DefaultConnectTimeout 默认连接超时
+const DefaultConnectTimeout = 10 * time.Second
+
+// ConnectTimeoutDuration 返回连接超时，未配置时返回 DefaultConnectTimeout
+func (c DatabaseConfig) ConnectTimeoutDuration() time.Duration {
+	if c.ConnectTimeout <= 0 {
+		return DefaultConnectTimeout
+	}
+	return time.Duration(c.ConnectTimeout) * time.Second
+}
+
+// QueryTimeoutDuration 返回元数据查询超时，0表示不限制
+func (c DatabaseConfig) QueryTimeoutDuration() time.Duration {
+	if c.QueryTimeout <= 0 {
+		return 0
+	}
+	return time.Duration(c.QueryTimeout) * time.Second
+}
+
+// Connect 解析环境变量和密钥引用后连接数据库，并设置模式和查询超时
+// 连接在 ConnectTimeoutDuration 内未建立时返回错误
+func (c DatabaseConfig) Connect(ctx context.Context) (*db.Database, error) {
+	c, err := c.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	dsn, err := c.DSN()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.ConnectTimeoutDuration())
+	defer cancel()
+
+	database, err := db.Connect(ctx, c.Type, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	database.SetSchema(c.Schema)
+	database.SetQueryTimeout(c.QueryTimeoutDuration())
+	return database, nil
 }
 
 // GeneratorConfig 生成器配置
 type GeneratorConfig struct {
-	PackageName string `yaml:"package_name"`
-	TagFormat   string `yaml:"tag_format"`
+	PackageName    string            `yaml:"package_name"`
+	TagFormat      string            `yaml:"tag_format"`
+	SchemaPackages map[string]string `yaml:"schema_packages,omitempty"` // 模式到Go包名的映射，对应的结构体输出到以包名命名的子目录
+	SchemaPrefixes map[string]string `yaml:"schema_prefixes,omitempty"` // 模式到结构体名前缀的映射
+	UUIDType       string            `yaml:"uuid_type,omitempty"`       // uuid列的Go类型，默认string，可带导入路径，如 github.com/google/uuid.UUID
+	JSONType       string            `yaml:"json_type,omitempty"`       // json/jsonb列的Go类型，默认json.RawMessage
+	DecimalType    string            `yaml:"decimal_type,omitempty"`    // decimal/numeric/money列的Go类型，默认float64，可设为string或带导入路径的类型，如 github.com/shopspring/decimal.Decimal
+	JSONTypes      map[string]string `yaml:"json_types,omitempty"`      // 按 表名.列名 指定JSON列的Go类型，当前包中的自定义结构体将生成 Scan/Value 方法
+	EnumScanner    bool              `yaml:"enum_scanner,omitempty"`    // 为枚举类型生成 Scan/Value 方法，读写数据库时校验取值
+	SoftDelete     *string           `yaml:"soft_delete,omitempty"`     // 软删除列名，标签格式包含gorm时该列生成为 gorm.DeletedAt，未设置时为 deleted_at，为空时不处理
+
+	Layout      string `yaml:"layout,omitempty"`       // 输出布局 per_table、single 或 prefix，默认 per_table
+	FilePattern string `yaml:"file_pattern,omitempty"` // 每表一个文件时的文件名模板，可用 .Table、.Schema、.Struct，默认 {{.Table}}_model.go
+	SingleFile  string `yaml:"single_file,omitempty"`  // single 布局下每个包的文件名，默认 models.go
+
+	// BaseModels 可嵌入的基础结构体，表包含其全部列时嵌入该结构体并省略这些列，按顺序使用第一个匹配的
+	BaseModels []BaseModel `yaml:"base_models,omitempty"`
+
+	// Tables 按表名或通配符指定的单表设置，多个配置匹配同一张表时按键名顺序合并，与表名完全相同的配置最后合并
+	Tables map[string]TableOverride `yaml:"tables,omitempty"`
+}
+
+// 输出布局
+const (
+	LayoutPerTable = "per_table" // 每张表一个文件
+	LayoutSingle   = "single"    // 同一包中的全部结构体写入一个文件，只在批量生成时有效，单表生成时仍为每表一个文件
+	LayoutPrefix   = "prefix"    // 按表名第一个下划线前的前缀分组到子包，每张表一个文件
+)
+
+// Layouts 可用的输出布局
+var Layouts = []string{LayoutPerTable, LayoutSingle, LayoutPrefix}
+
+// DefaultSoftDelete 未设置 soft_delete 时的软删除列名
+const DefaultSoftDelete = "deleted_at"
+
+// 默认的输出文件名
+const (
+	DefaultFilePattern = "{{.Table}}_model.go"
+	DefaultSingleFile  = "models.go"
+)
+
+// BaseModel 基础结构体及其包含的列
+type BaseModel struct {
+	Type    string   `yaml:"type"`    // 结构体类型，可带导入路径，如 gorm.io/gorm.Model
+	Columns []string `yaml:"columns"` // 结构体包含的列，不区分大小写
 }
 
-// Load 从文件加载配置
+// TableOverride 单表的生成设置，列相关的映射均以列名为键
+type TableOverride struct {
+	StructName string            `yaml:"struct_name,omitempty"` // 结构体名，默认由表名转换
+	OutputFile string            `yaml:"output_file,omitempty"` // 输出文件名，默认为 表名_model.go
+	Ignore     []string          `yaml:"ignore,omitempty"`      // 不生成字段的列，支持通配符
+	Fields     map[string]string `yaml:"fields,omitempty"`      // 列对应的字段名
+	Types      map[string]string `yaml:"types,omitempty"`       // 列对应的Go类型，可带导入路径，可空列仍使用指针
+	Tags       map[string]string `yaml:"tags,omitempty"`        // 列的字段追加的标签，如 validate:"required"
+	Embed      []string          `yaml:"embed,omitempty"`       // 嵌入到结构体中的类型，可带导入路径，如 gorm.io/gorm.Model
+}
+
+// SoftDeleteColumn 返回软删除列名，未设置时为 DefaultSoftDelete，为空时不处理软删除
+func (c GeneratorConfig) SoftDeleteColumn() string {
+	if c.SoftDelete == nil {
+		return DefaultSoftDelete
+	}
+	return *c.SoftDelete
+}
+
+// HasTag 标签格式中是否包含指定的标签
+func (c GeneratorConfig) HasTag(name string) bool {
+	for _, tag := range strings.Split(c.TagFormat, ",") {
+		if strings.TrimSpace(tag) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Load 从文件加载配置，拒绝未知的配置项并校验取值
+// 错误中包含文件名、行号和出错的配置项
 func Load(path string) (*Config, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var config Config
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
+	// 配置文件中没有的生成器设置使用默认值
+	config := Config{Generator: Default().Generator}
+	err = decode(path, data, &config)
+	errs, ok := err.(ValidationErrors)
+	if err != nil && !ok {
 		return nil, err
 	}
+	config.profileKeys(data)
 
+	// 未知配置项等错误不影响其余配置项的解码，一并校验以便一次报告全部问题
+	if invalid, ok := config.Validate().(ValidationErrors); ok {
+		errs = append(errs, invalid...)
+	}
+	if len(errs) > 0 {
+		errs.locate(path, data)
+		return nil, errs
+	}
 	return &config, nil
 }
 
+// Marshal 将配置编码为YAML
+func Marshal(cfg *Config) ([]byte, error) {
+	return yaml.Marshal(cfg)
+}
+
 // Save 保存配置到文件
 func Save(path string, cfg *Config) error {
-	data, err := yaml.Marshal(cfg)
+	data, err := Marshal(cfg)
 	if err != nil {
 		return err
 	}
@@ -54,12 +233,14 @@ func Save(path string, cfg *Config) error {
 func Default() *Config {
 	return &Config{
 		Database: DatabaseConfig{
-			Type:       "mysql",
-			Connection: "root:password@tcp(localhost:3306)/database",
+			Type: "mysql",
+			Host: "localhost",
+			Port: 3306,
+			User: "root",
 		},
 		Generator: GeneratorConfig{
 			PackageName: "model",
 			TagFormat:   "json,db",
 		},
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// FileName 项目目录下的配置文件名
+const FileName = "trade2sql.yaml"
+
+// legacyFileName 早期版本和GUI在当前目录使用的配置文件名
+const legacyFileName = "config.yaml"
+
+// 可以覆盖配置文件的环境变量
+const (
+	EnvConfig  = "TRADE2SQL_CONFIG"  // 配置文件路径
+	EnvProfile = "TRADE2SQL_PROFILE" // 使用的连接名
+)
+
+// dbEnvVars 覆盖数据库配置的环境变量
+var dbEnvVars = []struct {
+	name  string
+	field func(c *DatabaseConfig) *string
+}{
+	{"TRADE2SQL_DB_TYPE", func(c *DatabaseConfig) *string { return &c.Type }},
+	{"TRADE2SQL_DB_CONNECTION", func(c *DatabaseConfig) *string { return &c.Connection }},
+	{"TRADE2SQL_DB_SCHEMA", func(c *DatabaseConfig) *string { return &c.Schema }},
+	{"TRADE2SQL_DB_HOST", func(c *DatabaseConfig) *string { return &c.Host }},
+	{"TRADE2SQL_DB_USER", func(c *DatabaseConfig) *string { return &c.User }},
+	{"TRADE2SQL_DB_PASSWORD", func(c *DatabaseConfig) *string { return &c.Password }},
+	{"TRADE2SQL_DB_DATABASE", func(c *DatabaseConfig) *string { return &c.Database }},
+}
+
+// envPort 覆盖数据库端口的环境变量
+const envPort = "TRADE2SQL_DB_PORT"
+
+// Candidates 返回按优先级排列的配置文件候选路径
+// 依次为 TRADE2SQL_CONFIG 环境变量、当前目录的 trade2sql.yaml 和 config.yaml、用户配置目录的 trade2sql/config.yaml
+func Candidates() []string {
+	var paths []string
+	if path := os.Getenv(EnvConfig); path != "" {
+		paths = append(paths, path)
+	}
+	paths = append(paths, FileName, legacyFileName)
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "trade2sql", "config.yaml"))
+	}
+	return paths
+}
+
+// Find 查找配置文件，explicit不为空时只使用该文件且文件必须存在
+// 没有找到任何配置文件时返回空路径
+func Find(explicit string) (string, error) {
+	if explicit != "" {
+		if _, err := os.Stat(explicit); err != nil {
+			return "", fmt.Errorf("配置文件 %s 不存在: %v", explicit, err)
+		}
+		return explicit, nil
+	}
+
+	for _, path := range Candidates() {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// Discover 查找并加载配置文件，返回配置和配置文件路径
+// 没有配置文件时返回默认配置和空路径
+func Discover(explicit string) (*Config, string, error) {
+	path, err := Find(explicit)
+	if err != nil {
+		return nil, "", err
+	}
+	if path == "" {
+		return Default(), "", nil
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, path, fmt.Errorf("加载配置文件失败:\n%v", err)
+	}
+	return cfg, path, nil
+}
+
+// ApplyEnv 用 TRADE2SQL_DB_* 环境变量覆盖数据库配置，未设置的环境变量不影响配置
+func ApplyEnv(c *DatabaseConfig) error {
+	for _, env := range dbEnvVars {
+		if value, ok := os.LookupEnv(env.name); ok {
+			*env.field(c) = value
+		}
+	}
+
+	if value, ok := os.LookupEnv(envPort); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("环境变量 %s 必须是数字: %s", envPort, value)
+		}
+		c.Port = port
+	}
+	return nil
+}
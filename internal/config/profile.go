@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Profile 命名的连接配置
+type Profile struct {
+	Database  DatabaseConfig   `yaml:"database"`
+	Generator *GeneratorConfig `yaml:"generator,omitempty"` // 该连接专用的生成器配置，只覆盖其中设置了的项
+
+	// generatorKeys 配置文件中该连接的 generator 下出现的配置项，用于区分未设置和显式设置为零值的项
+	generatorKeys map[string]bool
+}
+
+// profileKeys 记录配置文件中各连接的 generator 下设置了的配置项
+func (c *Config) profileKeys(data []byte) {
+	var raw struct {
+		Profiles map[string]struct {
+			Generator map[string]interface{} `yaml:"generator"`
+		} `yaml:"profiles"`
+	}
+	if yaml.Unmarshal(data, &raw) != nil {
+		return
+	}
+
+	for name, p := range raw.Profiles {
+		profile, ok := c.Profiles[name]
+		if !ok || p.Generator == nil {
+			continue
+		}
+		profile.generatorKeys = map[string]bool{}
+		for key := range p.Generator {
+			profile.generatorKeys[key] = true
+		}
+		c.Profiles[name] = profile
+	}
+}
+
+// ProfileNames 返回所有连接名，按字母排序
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Select 返回指定连接的数据库配置和合并后的生成器配置
+// name为空时使用默认连接，没有默认连接时使用顶层的 database 配置
+func (c *Config) Select(name string) (DatabaseConfig, GeneratorConfig, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		return c.Database, c.Generator, nil
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return DatabaseConfig{}, GeneratorConfig{}, fmt.Errorf("配置中没有名为 %s 的连接，可用的连接: %s", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	generator := c.Generator
+	if profile.Generator != nil {
+		generator = mergeGenerator(c.Generator, *profile.Generator, profile.generatorKeys)
+	}
+	return profile.Database, generator, nil
+}
+
+// Use 将指定连接的配置应用到顶层的 Database 和 Generator，name为空时使用默认连接
+func (c *Config) Use(name string) error {
+	database, generator, err := c.Select(name)
+	if err != nil {
+		return err
+	}
+	c.Database = database
+	c.Generator = generator
+	return nil
+}
+
+// mergeGenerator 用override中设置了的项覆盖base，映射按键合并
+// keys为配置文件中出现的配置项，其中的项即使为 false 或空字符串也覆盖base；keys为nil时只覆盖非零值的项
+func mergeGenerator(base, override GeneratorConfig, keys map[string]bool) GeneratorConfig {
+	merged := base
+	dst := reflect.ValueOf(&merged).Elem()
+	src := reflect.ValueOf(override)
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if keys != nil {
+			name, _, _ := strings.Cut(src.Type().Field(i).Tag.Get("yaml"), ",")
+			if !keys[name] {
+				continue
+			}
+		} else if field.IsZero() {
+			continue
+		}
+
+		if field.Kind() == reflect.Map && !dst.Field(i).IsNil() {
+			m := reflect.MakeMap(field.Type())
+			for _, key := range dst.Field(i).MapKeys() {
+				m.SetMapIndex(key, dst.Field(i).MapIndex(key))
+			}
+			for _, key := range field.MapKeys() {
+				m.SetMapIndex(key, field.MapIndex(key))
+			}
+			dst.Field(i).Set(m)
+			continue
+		}
+		dst.Field(i).Set(field)
+	}
+	return merged
+}
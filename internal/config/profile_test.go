@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMergeGenerator(t *testing.T) {
+	base := GeneratorConfig{
+		PackageName:    "model",
+		TagFormat:      "json,gorm",
+		EnumScanner:    true,
+		JSONType:       "json.RawMessage",
+		SchemaPackages: map[string]string{"public": "model", "audit": "audit"},
+	}
+
+	tests := []struct {
+		name     string
+		override GeneratorConfig
+		keys     map[string]bool
+		want     GeneratorConfig
+	}{
+		{
+			name:     "没有记录配置项时只覆盖非零值",
+			override: GeneratorConfig{PackageName: "entity", TagFormat: ""},
+			want: GeneratorConfig{
+				PackageName:    "entity",
+				TagFormat:      "json,gorm",
+				EnumScanner:    true,
+				JSONType:       "json.RawMessage",
+				SchemaPackages: map[string]string{"public": "model", "audit": "audit"},
+			},
+		},
+		{
+			name:     "未设置的项保留",
+			override: GeneratorConfig{PackageName: "entity"},
+			keys:     map[string]bool{"package_name": true},
+			want: GeneratorConfig{
+				PackageName:    "entity",
+				TagFormat:      "json,gorm",
+				EnumScanner:    true,
+				JSONType:       "json.RawMessage",
+				SchemaPackages: map[string]string{"public": "model", "audit": "audit"},
+			},
+		},
+		{
+			name:     "显式设置为false和空字符串",
+			override: GeneratorConfig{TagFormat: "", EnumScanner: false, JSONType: ""},
+			keys:     map[string]bool{"tag_format": true, "enum_scanner": true, "json_type": true},
+			want: GeneratorConfig{
+				PackageName:    "model",
+				EnumScanner:    false,
+				SchemaPackages: map[string]string{"public": "model", "audit": "audit"},
+			},
+		},
+		{
+			name:     "映射按键合并",
+			override: GeneratorConfig{SchemaPackages: map[string]string{"audit": "logs", "sales": "sales"}},
+			keys:     map[string]bool{"schema_packages": true},
+			want: GeneratorConfig{
+				PackageName:    "model",
+				TagFormat:      "json,gorm",
+				EnumScanner:    true,
+				JSONType:       "json.RawMessage",
+				SchemaPackages: map[string]string{"public": "model", "audit": "logs", "sales": "sales"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeGenerator(base, tt.override, tt.keys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeGenerator() = %+v，期望 %+v", got, tt.want)
+			}
+		})
+	}
+
+	if len(base.SchemaPackages) != 2 || base.SchemaPackages["audit"] != "audit" {
+		t.Errorf("mergeGenerator() 修改了 base 的映射: %v", base.SchemaPackages)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	data := `database:
+  type: sqlite3
+  file: local.db
+generator:
+  package_name: model
+  tag_format: json,gorm
+  enum_scanner: true
+profiles:
+  dev:
+    database:
+      type: postgres
+      host: localhost
+  prod:
+    database:
+      type: mysql
+      host: db.example.com
+    generator:
+      tag_format: json
+      enum_scanner: false
+default_profile: dev
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() 错误: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		profile     string
+		wantType    string
+		wantTags    string
+		wantScanner bool
+		wantErr     bool
+	}{
+		{name: "默认连接", profile: "", wantType: "postgres", wantTags: "json,gorm", wantScanner: true},
+		{name: "覆盖生成器配置", profile: "prod", wantType: "mysql", wantTags: "json", wantScanner: false},
+		{name: "连接不存在", profile: "staging", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, generator, err := c.Select(tt.profile)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Select() 没有返回错误")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select() 错误: %v", err)
+			}
+			if database.Type != tt.wantType {
+				t.Errorf("数据库类型 = %s，期望 %s", database.Type, tt.wantType)
+			}
+			if generator.TagFormat != tt.wantTags {
+				t.Errorf("TagFormat = %q，期望 %q", generator.TagFormat, tt.wantTags)
+			}
+			if generator.EnumScanner != tt.wantScanner {
+				t.Errorf("EnumScanner = %v，期望 %v", generator.EnumScanner, tt.wantScanner)
+			}
+			if generator.PackageName != "model" {
+				t.Errorf("PackageName = %q，期望沿用顶层的 model", generator.PackageName)
+			}
+		})
+	}
+
+	// 没有默认连接时使用顶层配置
+	c.DefaultProfile = ""
+	if database, _, err := c.Select(""); err != nil || database.Type != "sqlite3" {
+		t.Errorf("Select(\"\") = %s, %v，期望顶层的 sqlite3", database.Type, err)
+	}
+}
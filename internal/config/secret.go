@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// envPattern 匹配 ${NAME} 形式的环境变量引用
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// 密钥引用前缀，用于密码和连接字符串
+const (
+	envRefPrefix  = "env:"  // env:NAME 读取环境变量
+	fileRefPrefix = "file:" // file:/path 读取文件内容，去掉首尾空白
+)
+
+// expandEnv 展开字符串中的 ${NAME}，引用的环境变量未设置时返回错误
+// 只识别带花括号的形式，密码中单独的 $ 保持原样
+func expandEnv(s string) (string, error) {
+	var missing string
+	expanded := envPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envPattern.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("环境变量 %s 未设置", missing)
+	}
+	return expanded, nil
+}
+
+// resolveSecret 解析 env: 和 file: 形式的密钥引用，其他值展开 ${NAME} 后原样返回
+func resolveSecret(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, envRefPrefix):
+		name := strings.TrimPrefix(s, envRefPrefix)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("环境变量 %s 未设置", name)
+		}
+		return value, nil
+	case strings.HasPrefix(s, fileRefPrefix):
+		path, err := expandEnv(strings.TrimPrefix(s, fileRefPrefix))
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("读取密钥文件失败: %v", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return expandEnv(s)
+	}
+}
+
+// IsSecretRef 判断值是否为 env:、file: 或 ${NAME} 形式的引用，引用本身不包含明文密钥
+func IsSecretRef(s string) bool {
+	return strings.HasPrefix(s, envRefPrefix) || strings.HasPrefix(s, fileRefPrefix) || envPattern.MatchString(s)
+}
+
+// Resolve 返回展开环境变量并解析密钥引用后的连接配置，原配置保持不变
+// 所有字符串字段都展开 ${NAME}，密码和连接字符串还可以写作 env:NAME 或 file:/path
+// SQLite的连接字符串本身可能是 file: URI，因此不作为密钥引用解析
+// 配置在内存和文件中始终保存引用，只在连接数据库时解析，保存配置不会写出明文密钥
+func (c DatabaseConfig) Resolve() (DatabaseConfig, error) {
+	resolved := c
+	var err error
+
+	for _, field := range []*string{
+		&resolved.Type, &resolved.Schema, &resolved.Host, &resolved.User,
+		&resolved.Database, &resolved.SSLMode, &resolved.Socket, &resolved.File,
+	} {
+		*field, err = expandEnv(*field)
+		if err != nil {
+			return c, err
+		}
+	}
+
+	if len(c.Params) > 0 {
+		resolved.Params = make(map[string]string, len(c.Params))
+		for k, v := range c.Params {
+			resolved.Params[k], err = expandEnv(v)
+			if err != nil {
+				return c, err
+			}
+		}
+	}
+
+	resolved.Password, err = resolveSecret(c.Password)
+	if err != nil {
+		return c, fmt.Errorf("解析数据库密码失败: %v", err)
+	}
+
+	if dialect, lookupErr := db.LookupDialect(resolved.Type); lookupErr == nil && dialect.Name() == "sqlite3" {
+		resolved.Connection, err = expandEnv(c.Connection)
+	} else {
+		resolved.Connection, err = resolveSecret(c.Connection)
+	}
+	if err != nil {
+		return c, fmt.Errorf("解析连接字符串失败: %v", err)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveSecret(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(secretFile, []byte("  s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TRADE2SQL_TEST_PASSWORD", "from-env")
+	t.Setenv("TRADE2SQL_TEST_DIR", dir)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "明文", value: "plain", want: "plain"},
+		{name: "单独的$保持原样", value: "pa$$word", want: "pa$$word"},
+		{name: "env引用", value: "env:TRADE2SQL_TEST_PASSWORD", want: "from-env"},
+		{name: "env引用未设置", value: "env:TRADE2SQL_TEST_MISSING", wantErr: true},
+		{name: "file引用去掉首尾空白", value: "file:" + secretFile, want: "s3cret"},
+		{name: "file路径中的环境变量", value: "file:${TRADE2SQL_TEST_DIR}/password", want: "s3cret"},
+		{name: "file不存在", value: "file:" + filepath.Join(dir, "missing"), wantErr: true},
+		{name: "展开环境变量", value: "pre-${TRADE2SQL_TEST_PASSWORD}", want: "pre-from-env"},
+		{name: "环境变量未设置", value: "${TRADE2SQL_TEST_MISSING}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSecret(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveSecret(%q) = %q，期望返回错误", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveSecret(%q) 错误: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveSecret(%q) = %q，期望 %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSecretRef(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "env:DB_PASSWORD", want: true},
+		{value: "file:/run/secrets/db", want: true},
+		{value: "${DB_PASSWORD}", want: true},
+		{value: "user:${DB_PASSWORD}@host", want: true},
+		{value: "s3cret", want: false},
+		{value: "$DB_PASSWORD", want: false},
+		{value: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsSecretRef(tt.value); got != tt.want {
+				t.Errorf("IsSecretRef(%q) = %v，期望 %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabaseConfigResolve(t *testing.T) {
+	t.Setenv("TRADE2SQL_TEST_HOST", "db.example.com")
+	t.Setenv("TRADE2SQL_TEST_PASSWORD", "s3cret")
+	t.Setenv("TRADE2SQL_TEST_CHARSET", "utf8mb4")
+
+	tests := []struct {
+		name    string
+		config  DatabaseConfig
+		want    DatabaseConfig
+		wantErr bool
+	}{
+		{
+			name: "展开字段和参数",
+			config: DatabaseConfig{
+				Type:     "mysql",
+				Host:     "${TRADE2SQL_TEST_HOST}",
+				Password: "env:TRADE2SQL_TEST_PASSWORD",
+				Params:   map[string]string{"charset": "${TRADE2SQL_TEST_CHARSET}"},
+			},
+			want: DatabaseConfig{
+				Type:     "mysql",
+				Host:     "db.example.com",
+				Password: "s3cret",
+				Params:   map[string]string{"charset": "utf8mb4"},
+			},
+		},
+		{
+			name:   "SQLite的file: URI不作为密钥引用",
+			config: DatabaseConfig{Type: "sqlite3", Connection: "file:test.db?cache=shared"},
+			want:   DatabaseConfig{Type: "sqlite3", Connection: "file:test.db?cache=shared"},
+		},
+		{
+			name:   "其他数据库的连接字符串可以引用密钥",
+			config: DatabaseConfig{Type: "postgres", Connection: "env:TRADE2SQL_TEST_PASSWORD"},
+			want:   DatabaseConfig{Type: "postgres", Connection: "s3cret"},
+		},
+		{
+			name:    "密码引用的环境变量未设置",
+			config:  DatabaseConfig{Type: "mysql", Password: "env:TRADE2SQL_TEST_MISSING"},
+			wantErr: true,
+		},
+		{
+			name:    "字段引用的环境变量未设置",
+			config:  DatabaseConfig{Type: "mysql", User: "${TRADE2SQL_TEST_MISSING}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.config
+			got, err := tt.config.Resolve()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Resolve() = %+v，期望返回错误", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() 错误: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %+v，期望 %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.config, original) {
+				t.Errorf("Resolve() 修改了原配置: %+v", tt.config)
+			}
+		})
+	}
+}
+
+func TestDatabaseConfigConnect(t *testing.T) {
+	t.Setenv("TRADE2SQL_TEST_DIR", t.TempDir())
+
+	cfg := DatabaseConfig{Type: "sqlite3", File: "${TRADE2SQL_TEST_DIR}/test.db"}
+	database, err := cfg.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("Connect() 错误: %v", err)
+	}
+	defer database.Close()
+
+	if _, err := database.DB().Exec("CREATE TABLE users (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv("TRADE2SQL_TEST_DIR"), "test.db")); err != nil {
+		t.Errorf("没有按展开后的路径创建数据库文件: %v", err)
+	}
+
+	cfg.File = "${TRADE2SQL_TEST_MISSING}/test.db"
+	if _, err := cfg.Connect(context.Background()); err == nil {
+		t.Error("Connect() 引用的环境变量未设置时没有返回错误")
+	}
+}
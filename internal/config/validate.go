@@ -0,0 +1,435 @@
+package config
+
+import (
+	"fmt"
+	"go/token"
+	pathpkg "path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/trade2sql/internal/db"
+	"gopkg.in/yaml.v2"
+)
+
+// KnownTags 结构体标签格式中允许使用的标签名
+var KnownTags = []string{"bson", "db", "form", "gorm", "json", "mapstructure", "msgpack", "toml", "validate", "xml", "xorm", "yaml"}
+
+// sslModes PostgreSQL支持的sslmode取值
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// ValidationError 配置校验错误，包含文件、行号和出错的配置项
+type ValidationError struct {
+	File string
+	Line int    // 0表示无法确定行号
+	Key  string // 出错的配置项，如 generator.tag_format
+	Msg  string
+
+	path []string // 配置项的各级键名，用于确定行号
+}
+
+// Error 实现 error 接口，格式为 文件:行号: 配置项: 错误信息
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		b.WriteString(e.File)
+		if e.Line > 0 {
+			b.WriteString(":" + strconv.Itoa(e.Line))
+		}
+		b.WriteString(": ")
+	}
+	if e.Key != "" {
+		b.WriteString(e.Key + ": ")
+	}
+	b.WriteString(e.Msg)
+	return b.String()
+}
+
+// ValidationErrors 一个配置文件中的全部校验错误
+type ValidationErrors []*ValidationError
+
+// Error 实现 error 接口，每行一个错误
+func (errs ValidationErrors) Error() string {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// unknownFieldPattern 匹配 yaml 严格解码时未知字段的错误
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type \S+$`)
+
+// linePattern 匹配 yaml 解码错误中的行号
+var linePattern = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// decode 严格解码配置，拒绝未知的配置项，错误中包含文件名和行号
+func decode(file string, data []byte, cfg *Config) error {
+	err := yaml.UnmarshalStrict(data, cfg)
+	if err == nil {
+		return nil
+	}
+
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok {
+		// 语法错误，yaml 的错误信息中已带有行号
+		return fmt.Errorf("%s: %v", file, err)
+	}
+
+	var errs ValidationErrors
+	for _, msg := range typeErr.Errors {
+		if m := unknownFieldPattern.FindStringSubmatch(msg); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			key := m[2]
+			// yaml 只报告出错的键名，按缩进补全上级配置项
+			if path := keyPath(data, line); len(path) > 0 && path[len(path)-1] == key {
+				key = strings.Join(path, ".")
+			}
+			errs = append(errs, &ValidationError{File: file, Line: line, Key: key, Msg: "未知的配置项，请检查拼写"})
+		} else if m := linePattern.FindStringSubmatch(msg); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			errs = append(errs, &ValidationError{File: file, Line: line, Msg: m[2]})
+		} else {
+			errs = append(errs, &ValidationError{File: file, Msg: msg})
+		}
+	}
+	return errs
+}
+
+// Validate 检查配置的取值，返回的 ValidationErrors 中没有文件和行号
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.database([]string{"database"}, c.Database, false)
+	v.generator([]string{"generator"}, c.Generator)
+
+	for _, name := range c.ProfileNames() {
+		profile := c.Profiles[name]
+		v.database([]string{"profiles", name, "database"}, profile.Database, true)
+		if profile.Generator != nil {
+			v.generator([]string{"profiles", name, "generator"}, *profile.Generator)
+		}
+	}
+
+	if c.DefaultProfile != "" {
+		if _, ok := c.Profiles[c.DefaultProfile]; !ok {
+			v.fail([]string{"default_profile"}, "没有名为 %s 的连接，可用的连接: %s", c.DefaultProfile, strings.Join(c.ProfileNames(), ", "))
+		}
+	}
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// locate 为校验错误补充配置文件名和行号，并按行号排序
+func (errs ValidationErrors) locate(file string, data []byte) {
+	for _, err := range errs {
+		err.File = file
+		if err.Line == 0 && len(err.path) > 0 {
+			err.Line = keyLine(data, err.path)
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+}
+
+// validator 收集校验错误
+type validator struct {
+	errs ValidationErrors
+}
+
+// fail 记录配置项path的错误
+func (v *validator) fail(path []string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Key:  strings.Join(path, "."),
+		Msg:  fmt.Sprintf(format, args...),
+		path: path,
+	})
+}
+
+// database 校验数据库配置，required表示必须指定数据库类型
+func (v *validator) database(path []string, c DatabaseConfig, required bool) {
+	key := func(name string) []string { return append(append([]string{}, path...), name) }
+
+	if c.Type == "" {
+		if required {
+			v.fail(key("type"), "必须指定数据库类型 %s", strings.Join(db.DialectNames(), ", "))
+		}
+	} else if !envPattern.MatchString(c.Type) {
+		if _, err := db.LookupDialect(c.Type); err != nil {
+			v.fail(key("type"), "不支持的数据库类型 %s，可用的类型: %s", c.Type, strings.Join(db.DialectNames(), ", "))
+		}
+	}
+
+	if c.Port < 0 || c.Port > 65535 {
+		v.fail(key("port"), "端口 %d 超出范围 0-65535", c.Port)
+	}
+	if c.ConnectTimeout < 0 {
+		v.fail(key("connect_timeout"), "超时秒数不能为负数")
+	}
+	if c.QueryTimeout < 0 {
+		v.fail(key("query_timeout"), "超时秒数不能为负数")
+	}
+	if c.SSLMode != "" && !envPattern.MatchString(c.SSLMode) && !containsString(sslModes, c.SSLMode) {
+		v.fail(key("sslmode"), "无效的sslmode %s，可用的取值: %s", c.SSLMode, strings.Join(sslModes, ", "))
+	}
+}
+
+// generator 校验生成器配置
+func (v *validator) generator(path []string, c GeneratorConfig) {
+	key := func(name ...string) []string { return append(append([]string{}, path...), name...) }
+
+	if c.PackageName != "" && !isPackageName(c.PackageName) {
+		v.fail(key("package_name"), "%s 不是有效的Go包名", c.PackageName)
+	}
+
+	for _, tag := range strings.Split(c.TagFormat, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !containsString(KnownTags, tag) {
+			v.fail(key("tag_format"), "未知的标签 %s，可用的标签: %s", tag, strings.Join(KnownTags, ", "))
+		}
+	}
+
+	// 模式对应的包名同时是输出子目录名
+	for schema, pkg := range c.SchemaPackages {
+		if !isPackageName(pkg) {
+			v.fail(key("schema_packages", schema), "%s 不是有效的Go包名，也不能用作输出目录", pkg)
+		}
+	}
+	for schema, prefix := range c.SchemaPrefixes {
+		if prefix == "" {
+			v.fail(key("schema_prefixes", schema), "结构体名前缀不能为空")
+		}
+	}
+
+	for column := range c.JSONTypes {
+		if table, col, ok := strings.Cut(column, "."); !ok || table == "" || col == "" {
+			v.fail(key("json_types", column), "键应为 表名.列名 的形式")
+		}
+	}
+
+	if c.Layout != "" && !containsString(Layouts, c.Layout) {
+		v.fail(key("layout"), "未知的输出布局 %s，可用的布局: %s", c.Layout, strings.Join(Layouts, ", "))
+	}
+	if c.FilePattern != "" {
+		if name, err := sampleFileName(c.FilePattern); err != nil {
+			v.fail(key("file_pattern"), "无效的文件名模板: %v", err)
+		} else if !isGoFile(name) {
+			v.fail(key("file_pattern"), "文件名模板生成的 %s 应为以 .go 结尾的相对路径，且不能以 _ext.go 结尾", name)
+		}
+	}
+	if c.SingleFile != "" && !isGoFile(c.SingleFile) {
+		v.fail(key("single_file"), "文件名 %s 应为以 .go 结尾的相对路径，且不能以 _ext.go 结尾", c.SingleFile)
+	}
+
+	for i, base := range c.BaseModels {
+		name := strconv.Itoa(i)
+		if strings.TrimSpace(base.Type) == "" {
+			v.fail(key("base_models", name, "type"), "基础结构体类型不能为空")
+		}
+		if len(base.Columns) == 0 {
+			v.fail(key("base_models", name, "columns"), "必须指定基础结构体包含的列")
+		}
+	}
+
+	for pattern, o := range c.Tables {
+		v.table(key("tables", pattern), pattern, o)
+	}
+}
+
+// table 校验单表设置
+func (v *validator) table(path []string, pattern string, o TableOverride) {
+	key := func(name ...string) []string { return append(append([]string{}, path...), name...) }
+
+	if _, err := pathpkg.Match(pattern, ""); err != nil {
+		v.fail(path, "无效的表名通配符 %s", pattern)
+	}
+	if o.StructName != "" && !token.IsIdentifier(o.StructName) {
+		v.fail(key("struct_name"), "%s 不是有效的Go标识符", o.StructName)
+	}
+	if o.OutputFile != "" && !isGoFile(o.OutputFile) {
+		v.fail(key("output_file"), "输出文件 %s 应为输出目录下以 .go 结尾的相对路径，且不能以 _ext.go 结尾", o.OutputFile)
+	}
+	for _, column := range o.Ignore {
+		if _, err := pathpkg.Match(column, ""); err != nil {
+			v.fail(key("ignore"), "无效的列名通配符 %s", column)
+		}
+	}
+	for column, name := range o.Fields {
+		if !token.IsIdentifier(name) {
+			v.fail(key("fields", column), "%s 不是有效的Go标识符", name)
+		}
+	}
+	for column, goType := range o.Types {
+		if strings.TrimSpace(goType) == "" {
+			v.fail(key("types", column), "类型不能为空")
+		}
+	}
+	for _, embed := range o.Embed {
+		if strings.TrimSpace(embed) == "" {
+			v.fail(key("embed"), "嵌入的类型不能为空")
+		}
+	}
+}
+
+// isGoFile 判断是否为输出目录下可以写入生成代码的Go文件路径，手写代码的 _ext.go 文件除外
+func isGoFile(name string) bool {
+	clean := pathpkg.Clean(filepath.ToSlash(name))
+	if filepath.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return false
+	}
+	return strings.HasSuffix(clean, ".go") && !strings.HasSuffix(clean, "_ext.go")
+}
+
+// sampleFileName 用示例数据执行文件名模板，检查模板能否正常使用
+func sampleFileName(pattern string) (string, error) {
+	tmpl, err := template.New("file").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	err = tmpl.Execute(&buf, map[string]string{"Table": "user", "Schema": "public", "Struct": "User"})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// isPackageName 判断是否为有效的Go包名
+func isPackageName(s string) bool {
+	return token.IsIdentifier(s) && s != "_" && !strings.ContainsFunc(s, func(r rune) bool { return r > 127 })
+}
+
+// containsString 检查切片中是否包含指定字符串
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// keyPath 返回YAML文本中第line行(从1开始)的配置项的各级键名，该行不是配置项时返回nil
+// 与 keyLine 相同，只处理块格式的映射，列表项的下标作为一级键名，如 generator.base_models.0.type
+func keyPath(data []byte, line int) []string {
+	lines := strings.Split(string(data), "\n")
+	if line < 1 || line > len(lines) {
+		return nil
+	}
+
+	indent, key, dash, ok := yamlKey(lines[line-1])
+	if !ok {
+		return nil
+	}
+	path := []string{key}
+	index := 0 // 当前列表项之前的同级列表项个数，dash为当前列表项 "-" 的缩进，-1表示不在列表项中
+	for i := line - 2; i >= 0 && indent > 0; i-- {
+		parentIndent, parent, parentDash, ok := yamlKey(lines[i])
+		switch {
+		case !ok:
+		case dash >= 0 && parentDash == dash:
+			index++
+		case dash < 0 && parentDash >= 0 && parentIndent == indent:
+			dash = parentDash
+		case parentIndent < indent:
+			if dash >= 0 {
+				path = append([]string{strconv.Itoa(index)}, path...)
+			}
+			path = append([]string{parent}, path...)
+			indent, dash, index = parentIndent, parentDash, 0
+		}
+	}
+	return path
+}
+
+// yamlKey 解析YAML的一行中的键名及其缩进，列表项 "- key:" 的缩进计到键名处，dash为 "-" 的缩进，不是列表项时为-1
+// 空行、注释和非映射行返回false
+func yamlKey(text string) (indent int, key string, dash int, ok bool) {
+	trimmed := strings.TrimLeft(text, " ")
+	indent = len(text) - len(trimmed)
+	dash = -1
+	if strings.HasPrefix(trimmed, "- ") {
+		dash = indent
+		rest := strings.TrimLeft(trimmed[2:], " ")
+		indent += len(trimmed) - len(rest)
+		trimmed = rest
+	}
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return 0, "", -1, false
+	}
+
+	key, _, ok = strings.Cut(trimmed, ":")
+	if !ok {
+		return 0, "", -1, false
+	}
+	return indent, strings.Trim(strings.TrimSpace(key), `"'`), dash, true
+}
+
+// keyLine 在YAML文本中查找配置项所在的行号，配置项不存在时返回其最近的上级配置项的行号，都找不到时返回0
+// 只处理块格式的映射和列表，数字键名表示列表项的下标，足以定位配置文件中的常见写法
+func keyLine(data []byte, path []string) int {
+	line := 0
+	depth := 0         // 已匹配的配置项层数
+	indents := []int{} // 已匹配的各层配置项的缩进，列表项为 "-" 的缩进
+	childIndent := -1  // 下一层配置项的缩进，-1表示尚未确定
+	item := -1         // 下一层为列表时已经过的列表项序号
+
+	for i, text := range strings.Split(string(data), "\n") {
+		indent, key, dash, ok := yamlKey(text)
+		if !ok {
+			continue
+		}
+		level := indent
+		if dash >= 0 {
+			level = dash
+		}
+
+		// 离开已匹配的上级配置项，说明下级中没有要找的配置项
+		if depth > 0 && level <= indents[depth-1] {
+			return line
+		}
+		if childIndent < 0 {
+			childIndent = level
+		}
+		if level != childIndent {
+			continue
+		}
+
+		if index, err := strconv.Atoi(path[depth]); err == nil {
+			if dash < 0 {
+				continue
+			}
+			item++
+			if item != index {
+				continue
+			}
+			line = i + 1
+			depth++
+			indents = append(indents, dash)
+			item = -1
+			if depth == len(path) {
+				return line
+			}
+			// 列表项的第一个键与 "-" 在同一行
+			childIndent = indent
+		}
+
+		if key == path[depth] {
+			line = i + 1
+			depth++
+			indents = append(indents, indent)
+			childIndent = -1
+			item = -1
+			if depth == len(path) {
+				return line
+			}
+		}
+	}
+	return line
+}
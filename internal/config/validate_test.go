@@ -0,0 +1,268 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/trade2sql/internal/db"
+)
+
+const sampleYAML = `database:
+  type: postgres
+  host: localhost
+generator:
+  package_name: model
+  base_models:
+    - type: gorm.io/gorm.Model
+      columns:
+        - id
+    - type: Audit
+      columns: [created_at]
+  tables:
+    users:
+      struct_name: Account
+profiles:
+  dev:
+    database:
+      type: sqlite3
+`
+
+func TestKeyPath(t *testing.T) {
+	tests := []struct {
+		name string
+		line int
+		want []string
+	}{
+		{name: "顶层配置项", line: 1, want: []string{"database"}},
+		{name: "二级配置项", line: 3, want: []string{"database", "host"}},
+		{name: "列表第一项", line: 7, want: []string{"generator", "base_models", "0", "type"}},
+		{name: "列表项中的下级", line: 8, want: []string{"generator", "base_models", "0", "columns"}},
+		{name: "列表第二项", line: 11, want: []string{"generator", "base_models", "1", "columns"}},
+		{name: "多级映射", line: 14, want: []string{"generator", "tables", "users", "struct_name"}},
+		{name: "不是配置项的行", line: 9, want: nil},
+		{name: "超出范围", line: 100, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyPath([]byte(sampleYAML), tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keyPath(%d) = %v，期望 %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyLine(t *testing.T) {
+	tests := []struct {
+		name string
+		path []string
+		want int
+	}{
+		{name: "顶层配置项", path: []string{"generator"}, want: 4},
+		{name: "二级配置项", path: []string{"database", "host"}, want: 3},
+		{name: "列表项", path: []string{"generator", "base_models", "1", "type"}, want: 10},
+		{name: "列表项中的下级", path: []string{"generator", "base_models", "1", "columns"}, want: 11},
+		{name: "多级映射", path: []string{"profiles", "dev", "database", "type"}, want: 18},
+		{name: "不存在时返回上级", path: []string{"generator", "tables", "orders"}, want: 12},
+		{name: "都不存在", path: []string{"missing"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyLine([]byte(sampleYAML), tt.path); got != tt.want {
+				t.Errorf("keyLine(%v) = %d，期望 %d", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   []string // 出错的配置项
+	}{
+		{
+			name:   "默认配置",
+			modify: func(c *Config) {},
+		},
+		{
+			name:   "不支持的数据库类型",
+			modify: func(c *Config) { c.Database.Type = "oracle" },
+			want:   []string{"database.type"},
+		},
+		{
+			name:   "数据库类型使用环境变量",
+			modify: func(c *Config) { c.Database.Type = "${DB_TYPE}" },
+		},
+		{
+			name: "端口和超时",
+			modify: func(c *Config) {
+				c.Database.Port = 70000
+				c.Database.ConnectTimeout = -1
+			},
+			want: []string{"database.port", "database.connect_timeout"},
+		},
+		{
+			name:   "未知的标签",
+			modify: func(c *Config) { c.Generator.TagFormat = "json, yml" },
+			want:   []string{"generator.tag_format"},
+		},
+		{
+			name:   "无效的包名",
+			modify: func(c *Config) { c.Generator.PackageName = "my-model" },
+			want:   []string{"generator.package_name"},
+		},
+		{
+			name:   "文件名模板引用未知字段",
+			modify: func(c *Config) { c.Generator.FilePattern = "{{.Name}}.go" },
+			want:   []string{"generator.file_pattern"},
+		},
+		{
+			name:   "文件名模板生成 _ext.go",
+			modify: func(c *Config) { c.Generator.FilePattern = "{{.Table}}_ext.go" },
+			want:   []string{"generator.file_pattern"},
+		},
+		{
+			name:   "输出到目录外",
+			modify: func(c *Config) { c.Generator.SingleFile = "../models.go" },
+			want:   []string{"generator.single_file"},
+		},
+		{
+			name:   "基础结构体缺少列",
+			modify: func(c *Config) { c.Generator.BaseModels = []BaseModel{{Type: "Audit"}} },
+			want:   []string{"generator.base_models.0.columns"},
+		},
+		{
+			name: "单表设置",
+			modify: func(c *Config) {
+				c.Generator.Tables = map[string]TableOverride{
+					"users": {StructName: "user-account", Fields: map[string]string{"id": "1d"}},
+				}
+			},
+			want: []string{"generator.tables.users.struct_name", "generator.tables.users.fields.id"},
+		},
+		{
+			name: "连接必须指定数据库类型",
+			modify: func(c *Config) {
+				c.Profiles = map[string]Profile{"dev": {Database: DatabaseConfig{Host: "localhost"}}}
+			},
+			want: []string{"profiles.dev.database.type"},
+		},
+		{
+			name: "连接的生成器配置",
+			modify: func(c *Config) {
+				c.Profiles = map[string]Profile{"dev": {
+					Database:  DatabaseConfig{Type: "sqlite3"},
+					Generator: &GeneratorConfig{Layout: "flat"},
+				}}
+			},
+			want: []string{"profiles.dev.generator.layout"},
+		},
+		{
+			name:   "默认连接不存在",
+			modify: func(c *Config) { c.DefaultProfile = "prod" },
+			want:   []string{"default_profile"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			tt.modify(c)
+
+			var got []string
+			if err := c.Validate(); err != nil {
+				errs, ok := err.(ValidationErrors)
+				if !ok {
+					t.Fatalf("Validate() 返回 %T，期望 ValidationErrors", err)
+				}
+				for _, e := range errs {
+					got = append(got, e.Key)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() 出错的配置项 = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []string // 完整的错误信息，每行一个
+	}{
+		{
+			name: "未知的配置项",
+			yaml: "database:\n  type: sqlite3\n  hots: localhost\n",
+			want: []string{"config.yaml:3: database.hots: 未知的配置项，请检查拼写"},
+		},
+		{
+			name: "列表项中未知的配置项",
+			yaml: "generator:\n  base_models:\n    - type: Audit\n      colums: [id]\n",
+			want: []string{
+				"config.yaml:3: generator.base_models.0.columns: 必须指定基础结构体包含的列",
+				"config.yaml:4: generator.base_models.0.colums: 未知的配置项，请检查拼写",
+			},
+		},
+		{
+			name: "未知配置项与取值错误一并报告",
+			yaml: "database:\n  type: oracle\ngenerator:\n  tag_fromat: json\n",
+			want: []string{
+				"config.yaml:2: database.type: 不支持的数据库类型 oracle，可用的类型: " + dialectList(),
+				"config.yaml:4: generator.tag_fromat: 未知的配置项，请检查拼写",
+			},
+		},
+		{
+			name: "连接中的错误",
+			yaml: "profiles:\n  dev:\n    database:\n      host: localhost\n",
+			want: []string{"config.yaml:3: profiles.dev.database.type: 必须指定数据库类型 " + dialectList()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(path)
+			if err == nil {
+				t.Fatal("Load() 没有返回错误")
+			}
+			got := strings.ReplaceAll(err.Error(), filepath.Dir(path)+string(filepath.Separator), "")
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Load() 错误 =\n%s\n期望\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadValid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(sampleYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() 错误: %v", err)
+	}
+	if c.Generator.TagFormat != Default().Generator.TagFormat {
+		t.Errorf("TagFormat = %q，期望使用默认值 %q", c.Generator.TagFormat, Default().Generator.TagFormat)
+	}
+	if got := c.Generator.Tables["users"].StructName; got != "Account" {
+		t.Errorf("users 的结构体名 = %q，期望 Account", got)
+	}
+}
+
+// dialectList 错误信息中列出的可用数据库类型
+func dialectList() string {
+	return strings.Join(db.DialectNames(), ", ")
+}
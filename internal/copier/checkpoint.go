@@ -0,0 +1,79 @@
+package copier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// checkpointTable 目标库中记录已复制行数的表
+// 与写入的数据在同一事务中更新，断点续传时以其为准，避免提交后进度文件未保存导致重复写入
+const checkpointTable = "trade2sql_copy_progress"
+
+// ensureCheckpoint 在目标库中创建进度表
+func (c *Copier) ensureCheckpoint(ctx context.Context) error {
+	exists, err := c.dst.TableExists(ctx, checkpointTable)
+	if err != nil || exists {
+		return err
+	}
+
+	dialect := c.dst.Dialect()
+	query := fmt.Sprintf("CREATE TABLE %s (\n\t%s %s NOT NULL,\n\t%s %s NOT NULL,\n\tPRIMARY KEY (%s)\n)",
+		c.dst.QuoteTable(checkpointTable),
+		c.dst.QuoteIdent("table_name"), dialect.ColumnType(db.KindString, true),
+		c.dst.QuoteIdent("copied_rows"), dialect.ColumnType(db.KindInt, false),
+		c.dst.QuoteIdent("table_name"))
+	_, err = c.dst.DB().ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("创建进度表失败: %v", err)
+	}
+	return nil
+}
+
+// checkpointRows 返回目标库中记录的表的已复制行数，没有记录时ok为false
+func (c *Copier) checkpointRows(ctx context.Context, table string) (rows int64, ok bool, err error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = %s",
+		c.dst.QuoteIdent("copied_rows"), c.dst.QuoteTable(checkpointTable), c.dst.QuoteIdent("table_name"), c.dst.Placeholder(1))
+	err = c.dst.DB().QueryRowContext(ctx, query, table).Scan(&rows)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return rows, true, nil
+}
+
+// saveCheckpoint 在事务中记录表的已复制行数
+// 先查询是否已有记录，不依赖 UPDATE 的影响行数: MySQL 默认返回实际修改的行数，值未变时为0
+func (c *Copier) saveCheckpoint(ctx context.Context, tx *sql.Tx, table string, rows int64) error {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = %s",
+		c.dst.QuoteTable(checkpointTable), c.dst.QuoteIdent("table_name"), c.dst.Placeholder(1))
+	var n int
+	err := tx.QueryRowContext(ctx, query, table).Scan(&n)
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		update := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s",
+			c.dst.QuoteTable(checkpointTable), c.dst.QuoteIdent("copied_rows"), c.dst.Placeholder(1),
+			c.dst.QuoteIdent("table_name"), c.dst.Placeholder(2))
+		_, err = tx.ExecContext(ctx, update, rows, table)
+		return err
+	}
+
+	insert := fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (%s, %s)",
+		c.dst.QuoteTable(checkpointTable), c.dst.QuoteIdent("table_name"), c.dst.QuoteIdent("copied_rows"),
+		c.dst.Placeholder(1), c.dst.Placeholder(2))
+	_, err = tx.ExecContext(ctx, insert, table, rows)
+	return err
+}
+
+// dropCheckpoint 全部表复制完成后删除进度表
+func (c *Copier) dropCheckpoint(ctx context.Context) error {
+	_, err := c.dst.DB().ExecContext(ctx, "DROP TABLE "+c.dst.QuoteTable(checkpointTable))
+	return err
+}
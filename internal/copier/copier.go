@@ -0,0 +1,325 @@
+package copier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// DefaultBatchSize 默认每批写入的行数
+const DefaultBatchSize = 500
+
+// Options 复制选项
+type Options struct {
+	Tables       []string                                 // 要复制的表，为空时复制源库全部表(不含视图)
+	BatchSize    int                                      // 每批写入的行数
+	Limit        int                                      // 每个表最多复制的行数，0表示不限制
+	Where        map[string]string                        // 按表名指定的过滤条件，表名可带或不带模式前缀
+	ProgressFile string                                   // 进度文件路径，用于断点续传
+	Logf         func(format string, args ...interface{}) // 进度输出
+}
+
+// Copier 在两个数据库连接之间复制数据
+type Copier struct {
+	src   *db.Database
+	dst   *db.Database
+	opts  Options
+	where map[string]string // 按复制的表名整理后的过滤条件
+}
+
+// New 创建复制器
+func New(src, dst *db.Database, opts Options) *Copier {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.Logf == nil {
+		opts.Logf = func(string, ...interface{}) {}
+	}
+
+	return &Copier{
+		src:  src,
+		dst:  dst,
+		opts: opts,
+	}
+}
+
+// Run 逐表复制数据
+func (c *Copier) Run(ctx context.Context) error {
+	tables := c.opts.Tables
+	if len(tables) == 0 {
+		all, err := c.src.GetTables(ctx)
+		if err != nil {
+			return fmt.Errorf("获取表列表失败: %v", err)
+		}
+		// 视图的数据来自其他表，不单独复制
+		for _, t := range all {
+			if !t.IsView() {
+				tables = append(tables, t.QualifiedName())
+			}
+		}
+	}
+
+	where, err := c.resolveWhere(tables)
+	if err != nil {
+		return err
+	}
+	c.where = where
+
+	progress, err := LoadProgress(c.opts.ProgressFile)
+	if err != nil {
+		return fmt.Errorf("加载进度文件失败: %v", err)
+	}
+	if c.resumable() {
+		err = c.ensureCheckpoint(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, table := range tables {
+		tp := progress.Table(table)
+		if tp.Done {
+			c.opts.Logf("跳过已完成的表 %s (%d 行)\n", table, tp.Rows)
+			continue
+		}
+
+		err := c.copyTable(ctx, table, tp, progress)
+		if err != nil {
+			return fmt.Errorf("复制表 %s 失败: %v", table, err)
+		}
+	}
+
+	if c.resumable() {
+		return c.dropCheckpoint(ctx)
+	}
+	return nil
+}
+
+// resolveWhere 将过滤条件对应到要复制的表
+// 条件的表名可以带模式前缀，也可以只写表名，同时指定时以带模式前缀的为准
+// 没有对应任何要复制的表的条件视为拼写错误，避免在不加过滤的情况下复制全表
+func (c *Copier) resolveWhere(tables []string) (map[string]string, error) {
+	where := make(map[string]string)
+	used := make(map[string]bool)
+	for _, table := range tables {
+		if cond, ok := c.opts.Where[table]; ok {
+			where[table] = cond
+			used[table] = true
+			continue
+		}
+		_, name := c.src.SplitTableName(table)
+		if cond, ok := c.opts.Where[name]; ok {
+			where[table] = cond
+			used[name] = true
+		}
+	}
+
+	var unknown []string
+	for key := range c.opts.Where {
+		if !used[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("过滤条件中的表不在要复制的表中: %s", strings.Join(unknown, ", "))
+	}
+	return where, nil
+}
+
+// resumable 是否支持断点续传，指定了进度文件时在目标库中同步记录进度
+func (c *Copier) resumable() bool {
+	return c.opts.ProgressFile != ""
+}
+
+// copyTable 复制单个表
+func (c *Copier) copyTable(ctx context.Context, table string, tp *TableProgress, progress *Progress) error {
+	all, err := c.src.GetTableInfo(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	// 生成列的值由数据库计算，不参与复制
+	var columns []db.ColumnInfo
+	for _, col := range all {
+		if !col.IsGenerated {
+			columns = append(columns, col)
+		}
+	}
+
+	// 目标表不带源库的模式前缀，位于目标库的默认模式中
+	_, dstTable := c.src.SplitTableName(table)
+	exists, err := c.dst.TableExists(ctx, dstTable)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		c.opts.Logf("在目标库中创建表 %s\n", dstTable)
+		_, err = c.dst.DB().ExecContext(ctx, createTableSQL(c.dst, dstTable, columns))
+		if err != nil {
+			return fmt.Errorf("创建表失败: %v", err)
+		}
+	}
+
+	names := make([]string, len(columns))
+	kinds := make([]db.Kind, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+		kinds[i] = db.ColumnKind(col)
+	}
+	var orderBy []string
+	for _, col := range primaryKey(columns) {
+		orderBy = append(orderBy, col.Name)
+	}
+	// 没有主键时按全部列排序，保证断点续传时的读取顺序稳定
+	if len(orderBy) == 0 {
+		orderBy = names
+	}
+
+	// 已提交的行数以目标库中的记录为准，进度文件可能落后于最后一次提交
+	if c.resumable() {
+		rows, ok, err := c.checkpointRows(ctx, table)
+		if err != nil {
+			return fmt.Errorf("读取进度失败: %v", err)
+		}
+		if ok {
+			tp.Rows = rows
+		}
+	}
+
+	limit := 0
+	if c.opts.Limit > 0 {
+		limit = c.opts.Limit - int(tp.Rows)
+		if limit <= 0 {
+			tp.Done = true
+			return progress.Save()
+		}
+	}
+
+	rows, err := c.src.Select(ctx, db.SelectQuery{
+		Table:   table,
+		Columns: names,
+		Where:   c.where[table],
+		OrderBy: orderBy,
+		Limit:   limit,
+		Offset:  int(tp.Rows),
+	})
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batchSize := c.opts.BatchSize
+	if maxRows := c.dst.MaxParams() / len(columns); batchSize > maxRows {
+		batchSize = maxRows
+	}
+	if maxRows := c.dst.MaxRows(); maxRows > 0 && batchSize > maxRows {
+		batchSize = maxRows
+	}
+
+	batch := make([]interface{}, 0, batchSize*len(columns))
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n := len(batch) / len(columns)
+		err := c.insertBatch(ctx, dstTable, names, batch, func(tx *sql.Tx) error {
+			if !c.resumable() {
+				return nil
+			}
+			return c.saveCheckpoint(ctx, tx, table, tp.Rows+int64(n))
+		})
+		if err != nil {
+			return err
+		}
+		batch = batch[:0]
+
+		tp.Rows += int64(n)
+		c.opts.Logf("表 %s 已复制 %d 行\n", table, tp.Rows)
+		return progress.Save()
+	}
+
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		err := rows.Scan(ptrs...)
+		if err != nil {
+			return err
+		}
+
+		for i, v := range values {
+			converted, err := db.NormalizeValue(v, kinds[i])
+			if err != nil {
+				return fmt.Errorf("转换列 %s 的值失败: %v", names[i], err)
+			}
+			// MySQL的零值日期在其他数据库中无效，可空列写入NULL
+			if t, ok := converted.(time.Time); ok && t.IsZero() && columns[i].IsNullable {
+				converted = nil
+			}
+			batch = append(batch, converted)
+		}
+
+		if len(batch) >= batchSize*len(columns) {
+			err := flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	err = flush()
+	if err != nil {
+		return err
+	}
+
+	tp.Done = true
+	return progress.Save()
+}
+
+// insertBatch 在一个事务中批量写入多行，checkpoint在同一事务中记录进度
+func (c *Copier) insertBatch(ctx context.Context, table string, columns []string, values []interface{}, checkpoint func(tx *sql.Tx) error) error {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = c.dst.QuoteIdent(col)
+	}
+
+	var tuples []string
+	for row := 0; row < len(values)/len(columns); row++ {
+		placeholders := make([]string, len(columns))
+		for i := range columns {
+			placeholders[i] = c.dst.Placeholder(row*len(columns) + i + 1)
+		}
+		tuples = append(tuples, "("+strings.Join(placeholders, ", ")+")")
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		c.dst.QuoteTable(table), strings.Join(quoted, ", "), strings.Join(tuples, ", "))
+
+	tx, err := c.dst.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, values...)
+	if err == nil {
+		err = checkpoint(tx)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
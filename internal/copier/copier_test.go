@@ -0,0 +1,196 @@
+package copier
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// openSQLite 在临时目录中创建SQLite数据库并执行初始化语句
+func openSQLite(t *testing.T, name string, statements ...string) *db.Database {
+	t.Helper()
+	database, err := db.Connect(context.Background(), "sqlite", filepath.Join(t.TempDir(), name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	for _, stmt := range statements {
+		_, err := database.DB().Exec(stmt)
+		if err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	return database
+}
+
+// count 返回表中满足条件的行数
+func count(t *testing.T, database *db.Database, query string) int {
+	t.Helper()
+	var n int
+	err := database.DB().QueryRow(query).Scan(&n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestCopyResume(t *testing.T) {
+	src := openSQLite(t, "src.db",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)",
+		"WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 25) INSERT INTO users SELECT i, 'u' || i FROM n",
+	)
+
+	tests := []struct {
+		name  string
+		setup []string
+	}{
+		{name: "全新复制"},
+		{
+			// 上次运行提交了前两批，但进程在保存进度文件前退出
+			name: "进度文件落后于已提交的数据",
+			setup: []string{
+				"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)",
+				"WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 20) INSERT INTO users SELECT i, 'u' || i FROM n",
+				"CREATE TABLE " + checkpointTable + " (table_name TEXT PRIMARY KEY, copied_rows INTEGER NOT NULL)",
+				"INSERT INTO " + checkpointTable + " VALUES ('users', 20)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := openSQLite(t, "dst.db", tt.setup...)
+			progressFile := filepath.Join(t.TempDir(), "progress.json")
+
+			err := New(src, dst, Options{BatchSize: 10, ProgressFile: progressFile}).Run(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if n := count(t, dst, "SELECT COUNT(*) FROM users"); n != 25 {
+				t.Errorf("目标表有 %d 行，期望 25 行", n)
+			}
+			exists, err := dst.TableExists(context.Background(), checkpointTable)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists {
+				t.Errorf("复制完成后应删除进度表 %s", checkpointTable)
+			}
+		})
+	}
+}
+
+func TestCopyZeroDates(t *testing.T) {
+	src := openSQLite(t, "src.db",
+		"CREATE TABLE events (id INTEGER PRIMARY KEY, happened_at DATETIME, created_at DATETIME NOT NULL)",
+		"INSERT INTO events VALUES (1, '0000-00-00 00:00:00', '0000-00-00'), (2, '2024-03-01 08:30:00', '2024-03-01')",
+	)
+	dst := openSQLite(t, "dst.db")
+
+	err := New(src, dst, Options{}).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := count(t, dst, "SELECT COUNT(*) FROM events WHERE happened_at IS NULL"); n != 1 {
+		t.Errorf("可空列中的零值日期应写入NULL，实际有 %d 行为NULL", n)
+	}
+	if n := count(t, dst, "SELECT COUNT(*) FROM events WHERE created_at IS NOT NULL"); n != 2 {
+		t.Errorf("非空列中的零值日期应写入零值时间，实际有 %d 行不为NULL", n)
+	}
+}
+
+func TestResolveWhere(t *testing.T) {
+	src, err := db.NewDatabase(nil, "postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := []string{"sales.orders", "audit.orders", "public.users"}
+
+	tests := []struct {
+		name    string
+		where   map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "带模式前缀",
+			where: map[string]string{"sales.orders": "id > 10"},
+			want:  map[string]string{"sales.orders": "id > 10"},
+		},
+		{
+			name:  "只写表名时对应各模式中的同名表",
+			where: map[string]string{"orders": "id > 10", "users": "active"},
+			want:  map[string]string{"sales.orders": "id > 10", "audit.orders": "id > 10", "public.users": "active"},
+		},
+		{
+			name:  "带模式前缀的优先",
+			where: map[string]string{"orders": "id > 10", "audit.orders": "id > 20"},
+			want:  map[string]string{"sales.orders": "id > 10", "audit.orders": "id > 20"},
+		},
+		{
+			name:    "表不在要复制的表中",
+			where:   map[string]string{"order": "id > 10"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(src, nil, Options{Where: tt.where})
+			got, err := c.resolveWhere(tables)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("resolveWhere() 没有返回错误")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveWhere() = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveCheckpoint(t *testing.T) {
+	dst := openSQLite(t, "dst.db")
+	c := New(nil, dst, Options{ProgressFile: filepath.Join(t.TempDir(), "progress.json")})
+	ctx := context.Background()
+	if err := c.ensureCheckpoint(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 同一行数保存两次时不应重复插入
+	for _, rows := range []int64{10, 10, 20} {
+		tx, err := dst.DB().BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.saveCheckpoint(ctx, tx, "users", rows); err != nil {
+			tx.Rollback()
+			t.Fatalf("saveCheckpoint(%d) 错误: %v", rows, err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rows, ok, err := c.checkpointRows(ctx, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || rows != 20 {
+		t.Errorf("checkpointRows() = %d, %v，期望 20, true", rows, ok)
+	}
+	if n := count(t, dst, "SELECT COUNT(*) FROM "+checkpointTable); n != 1 {
+		t.Errorf("进度表有 %d 行，期望 1 行", n)
+	}
+}
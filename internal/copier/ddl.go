@@ -0,0 +1,45 @@
+package copier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// createTableSQL 根据源表结构生成目标库的建表语句
+func createTableSQL(dst *db.Database, tableName string, columns []db.ColumnInfo) string {
+	var defs []string
+	for _, col := range columns {
+		def := fmt.Sprintf("%s %s", dst.QuoteIdent(col.Name), dst.Dialect().ColumnType(db.ColumnKind(col), col.IsPrimary))
+		if !col.IsNullable {
+			def += " NOT NULL"
+		}
+		defs = append(defs, def)
+	}
+
+	var primaryKeys []string
+	for _, col := range primaryKey(columns) {
+		primaryKeys = append(primaryKeys, dst.QuoteIdent(col.Name))
+	}
+	if len(primaryKeys) > 0 {
+		defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", dst.QuoteTable(tableName), strings.Join(defs, ",\n\t"))
+}
+
+// primaryKey 返回主键列，联合主键按其在主键中的顺序排序
+func primaryKey(columns []db.ColumnInfo) []db.ColumnInfo {
+	var primary []db.ColumnInfo
+	for _, col := range columns {
+		if col.IsPrimary {
+			primary = append(primary, col)
+		}
+	}
+	sort.SliceStable(primary, func(i, j int) bool {
+		return primary[i].PKOrdinal < primary[j].PKOrdinal
+	})
+	return primary
+}
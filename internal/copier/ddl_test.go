@@ -0,0 +1,59 @@
+package copier
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trade2sql/internal/db"
+)
+
+func TestCreateTableSQL(t *testing.T) {
+	dst := openSQLite(t, "dst.db")
+
+	tests := []struct {
+		name    string
+		columns []db.ColumnInfo
+		want    string // 期望的主键定义，为空时不应有主键
+	}{
+		{
+			name: "联合主键按主键中的顺序",
+			columns: []db.ColumnInfo{
+				{Name: "tenant_id", Type: "integer", IsPrimary: true, PKOrdinal: 2},
+				{Name: "name", Type: "text", IsNullable: true},
+				{Name: "order_no", Type: "text", IsPrimary: true, PKOrdinal: 1},
+			},
+			want: `PRIMARY KEY ("order_no", "tenant_id")`,
+		},
+		{
+			name: "顺序未知时按列顺序",
+			columns: []db.ColumnInfo{
+				{Name: "a", Type: "integer", IsPrimary: true},
+				{Name: "b", Type: "integer", IsPrimary: true},
+			},
+			want: `PRIMARY KEY ("a", "b")`,
+		},
+		{
+			name:    "没有主键",
+			columns: []db.ColumnInfo{{Name: "a", Type: "integer"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := createTableSQL(dst, "items", tt.columns)
+			if tt.want == "" {
+				if strings.Contains(got, "PRIMARY KEY") {
+					t.Errorf("createTableSQL() = %s，期望没有主键", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("createTableSQL() = %s，期望包含 %s", got, tt.want)
+			}
+			if _, err := dst.DB().Exec(got); err != nil {
+				t.Errorf("建表失败: %v", err)
+			}
+			dst.DB().Exec("DROP TABLE items")
+		})
+	}
+}
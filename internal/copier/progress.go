@@ -0,0 +1,71 @@
+package copier
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Progress 复制进度，用于断点续传
+type Progress struct {
+	path   string
+	Tables map[string]*TableProgress `json:"tables"`
+}
+
+// TableProgress 单个表的复制进度
+type TableProgress struct {
+	Rows int64 `json:"rows"`
+	Done bool  `json:"done"`
+}
+
+// LoadProgress 从文件加载进度，文件不存在时返回空进度
+func LoadProgress(path string) (*Progress, error) {
+	p := &Progress{
+		path:   path,
+		Tables: map[string]*TableProgress{},
+	}
+	if path == "" {
+		return p, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, p)
+	if err != nil {
+		return nil, err
+	}
+	if p.Tables == nil {
+		p.Tables = map[string]*TableProgress{}
+	}
+
+	return p, nil
+}
+
+// Table 返回指定表的进度
+func (p *Progress) Table(tableName string) *TableProgress {
+	tp, ok := p.Tables[tableName]
+	if !ok {
+		tp = &TableProgress{}
+		p.Tables[tableName] = tp
+	}
+	return tp
+}
+
+// Save 保存进度到文件，未指定文件时不做任何操作
+func (p *Progress) Save() error {
+	if p.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.path, data, 0644)
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultWorkers 并发读取表结构时默认的协程数
+const DefaultWorkers = 8
+
+// EachTable 使用最多workers个协程并发处理多张表，i为表在names中的下标
+// 任一表处理出错时取消其余处理，返回第一个错误
+// 高延迟网络下逐表串行查询很慢，并发查询可以显著缩短读取大量表结构的时间
+func EachTable(ctx context.Context, names []string, workers int, fn func(ctx context.Context, i int, name string) error) error {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				err := fn(ctx, i, names[i])
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range names {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// GetTableInfos 并发读取多张表的列信息，结果与names一一对应
+func (d *Database) GetTableInfos(ctx context.Context, names []string, workers int) ([][]ColumnInfo, error) {
+	infos := make([][]ColumnInfo, len(names))
+	err := EachTable(ctx, names, workers, func(ctx context.Context, i int, name string) error {
+		columns, err := d.GetTableInfo(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取表 %s 结构失败: %v", name, err)
+		}
+		infos[i] = columns
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
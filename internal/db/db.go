@@ -1,300 +1,148 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-
-	_ "github.com/go-sql-driver/mysql"
-	_ "github.com/lib/pq"
-	_ "github.com/mattn/go-sqlite3"
+	"time"
 )
 
 // Database 数据库接口
 type Database struct {
-	db     *sql.DB
-	dbType string
+	db           *sql.DB
+	dialect      Dialect
+	schema       string
+	queryTimeout time.Duration
 }
 
-// Connect 连接到数据库
-func Connect(dbType, connStr string) (*Database, error) {
-	db, err := sql.Open(dbType, connStr)
+// Connect 连接到数据库，dbType可以是方言名或其别名
+// 连接在ctx取消或超时后放弃，调用方可通过ctx设置连接超时
+func Connect(ctx context.Context, dbType, connStr string) (*Database, error) {
+	dialect, err := LookupDialect(dbType)
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.Ping()
+	db, err := sql.Open(dialect.DriverName(), connStr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Database{
-		db:     db,
-		dbType: dbType,
-	}, nil
-}
-
-// Close 关闭数据库连接
-func (d *Database) Close() error {
-	return d.db.Close()
-}
-
-// GetTableInfo 获取表结构信息
-func (d *Database) GetTableInfo(tableName string) ([]ColumnInfo, error) {
-	switch d.dbType {
-	case "mysql":
-		return d.getMySQLTableInfo(tableName)
-	case "postgres":
-		return d.getPostgresTableInfo(tableName)
-	case "sqlite3":
-		return d.getSQLiteTableInfo(tableName)
-	default:
-		return nil, fmt.Errorf("不支持的数据库类型: %s", d.dbType)
-	}
-}
-
-// ColumnInfo 列信息
-type ColumnInfo struct {
-	Name       string
-	Type       string
-	IsNullable bool
-	IsPrimary  bool
-	Comment    string
-}
-
-// getMySQLTableInfo 获取MySQL表结构
-func (d *Database) getMySQLTableInfo(tableName string) ([]ColumnInfo, error) {
-	query := `
-		SELECT 
-			COLUMN_NAME, 
-			DATA_TYPE, 
-			IS_NULLABLE, 
-			COLUMN_KEY, 
-			COLUMN_COMMENT
-		FROM 
-			INFORMATION_SCHEMA.COLUMNS 
-		WHERE 
-			TABLE_NAME = ? 
-		ORDER BY 
-			ORDINAL_POSITION
-	`
-
-	rows, err := d.db.Query(query, tableName)
+	err = db.PingContext(ctx)
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
-	defer rows.Close()
-
-	var columns []ColumnInfo
-	for rows.Next() {
-		var col ColumnInfo
-		var isNullable, columnKey string
-		err := rows.Scan(&col.Name, &col.Type, &isNullable, &columnKey, &col.Comment)
-		if err != nil {
-			return nil, err
-		}
-
-		col.IsNullable = isNullable == "YES"
-		col.IsPrimary = columnKey == "PRI"
-		columns = append(columns, col)
-	}
 
-	return columns, nil
+	return &Database{
+		db:      db,
+		dialect: dialect,
+	}, nil
 }
 
-// getPostgresTableInfo 获取PostgreSQL表结构
-func (d *Database) getPostgresTableInfo(tableName string) ([]ColumnInfo, error) {
-	query := `
-		SELECT 
-			a.attname AS column_name,
-			format_type(a.atttypid, a.atttypmod) AS data_type,
-			CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END AS is_nullable,
-			CASE WHEN p.contype = 'p' THEN 'PRI' ELSE '' END AS column_key,
-			(SELECT pg_catalog.col_description(a.attrelid, a.attnum)) AS column_comment
-		FROM 
-			pg_catalog.pg_attribute a
-		LEFT JOIN 
-			pg_catalog.pg_constraint p ON p.conrelid = a.attrelid AND a.attnum = ANY(p.conkey)
-		WHERE 
-			a.attrelid = $1::regclass
-			AND a.attnum > 0
-			AND NOT a.attisdropped
-		ORDER BY 
-			a.attnum
-	`
-
-	rows, err := d.db.Query(query, tableName)
+// NewDatabase 使用已打开的连接创建Database，dbType可以是方言名或其别名
+// 连接由调用方打开，可用于复用连接池或在测试中注入模拟驱动，Close 时一并关闭
+func NewDatabase(db *sql.DB, dbType string) (*Database, error) {
+	dialect, err := LookupDialect(dbType)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var columns []ColumnInfo
-	for rows.Next() {
-		var col ColumnInfo
-		var isNullable, columnKey string
-		err := rows.Scan(&col.Name, &col.Type, &isNullable, &columnKey, &col.Comment)
-		if err != nil {
-			return nil, err
-		}
-
-		col.IsNullable = isNullable == "YES"
-		col.IsPrimary = columnKey == "PRI"
-		columns = append(columns, col)
-	}
 
-	return columns, nil
+	return &Database{
+		db:      db,
+		dialect: dialect,
+	}, nil
 }
 
-// getSQLiteTableInfo 获取SQLite表结构
-func (d *Database) getSQLiteTableInfo(tableName string) ([]ColumnInfo, error) {
-	query := fmt.Sprintf("PRAGMA table_info(%s)", tableName)
-	rows, err := d.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var columns []ColumnInfo
-	for rows.Next() {
-		var cid int
-		var name, dataType string
-		var notNull, pk int
-		var dfltValue interface{}
-
-		err := rows.Scan(&cid, &name, &dataType, &notNull, &dfltValue, &pk)
-		if err != nil {
-			return nil, err
-		}
+// SetSchema 设置默认模式，仅对支持模式的数据库有效
+// 设置后只列出该模式下的表，未限定模式的表名也在该模式中查找
+func (d *Database) SetSchema(schema string) {
+	d.schema = schema
+}
 
-		col := ColumnInfo{
-			Name:       name,
-			Type:       dataType,
-			IsNullable: notNull == 0,
-			IsPrimary:  pk > 0,
-			Comment:    "",
-		}
-		columns = append(columns, col)
-	}
+// Schema 返回默认模式
+func (d *Database) Schema() string {
+	return d.schema
+}
 
-	return columns, nil
+// SetQueryTimeout 设置元数据查询的超时时间，0表示不限制
+// 超时只作用于读取表结构等元数据的查询，不限制 Select 读取数据
+func (d *Database) SetQueryTimeout(timeout time.Duration) {
+	d.queryTimeout = timeout
 }
 
-// GetTableList 获取数据库中的所有表
-func (d *Database) GetTableList() ([]string, error) {
-	switch d.dbType {
-	case "mysql":
-		return d.getMySQLTableList()
-	case "postgres":
-		return d.getPostgresTableList()
-	case "sqlite3":
-		return d.getSQLiteTableList()
-	default:
-		return nil, fmt.Errorf("不支持的数据库类型: %s", d.dbType)
+// withTimeout 为元数据查询附加超时
+func (d *Database) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, d.queryTimeout)
 }
 
-// getMySQLTableList 获取MySQL数据库中的所有表
-func (d *Database) getMySQLTableList() ([]string, error) {
-	// 获取当前数据库名
-	var dbName string
-	err := d.db.QueryRow("SELECT DATABASE()").Scan(&dbName)
-	if err != nil {
-		return nil, err
-	}
+// Close 关闭数据库连接
+func (d *Database) Close() error {
+	return d.db.Close()
+}
 
-	// 查询所有表
-	query := `
-		SELECT 
-			TABLE_NAME 
-		FROM 
-			INFORMATION_SCHEMA.TABLES 
-		WHERE 
-			TABLE_SCHEMA = ? 
-		ORDER BY 
-			TABLE_NAME
-	`
+// GetTableInfo 获取表结构信息，各方言查询不存在的表时都不返回列，统一报告表不存在
+func (d *Database) GetTableInfo(ctx context.Context, tableName string) ([]ColumnInfo, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
 
-	rows, err := d.db.Query(query, dbName)
+	schema, table := d.SplitTableName(tableName)
+	columns, err := d.dialect.Columns(ctx, d, schema, table)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var tables []string
-	for rows.Next() {
-		var tableName string
-		err := rows.Scan(&tableName)
-		if err != nil {
-			return nil, err
-		}
-		tables = append(tables, tableName)
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("表 %s 不存在或没有列", tableName)
 	}
 
-	return tables, nil
+	for i := range columns {
+		columns[i].GoType = d.dialect.GoType(columns[i])
+	}
+	return columns, nil
 }
 
-// getPostgresTableList 获取PostgreSQL数据库中的所有表
-func (d *Database) getPostgresTableList() ([]string, error) {
-	query := `
-		SELECT 
-			tablename 
-		FROM 
-			pg_catalog.pg_tables 
-		WHERE 
-			schemaname != 'pg_catalog' 
-			AND schemaname != 'information_schema' 
-		ORDER BY 
-			tablename
-	`
+// ColumnInfo 列信息
+type ColumnInfo struct {
+	Name        string
+	Type        string
+	IsNullable  bool
+	IsPrimary   bool
+	Comment     string
+	Default     *string  // 默认值，nil表示没有默认值
+	UDTName     string   // 列声明的类型名，如PostgreSQL的域、枚举或数组类型名
+	BaseType    string   // 域类型的基础类型
+	IsArray     bool     // 是否为数组
+	ElemType    string   // 数组元素类型
+	EnumName    string   // 枚举类型名
+	EnumValues  []string // 枚举的取值
+	IsSet       bool     // 是否为MySQL的SET类型，取值为以逗号分隔的多个成员
+	PKOrdinal   int      // 在主键中的位置，从1开始，0表示未知或不是主键列
+	IsGenerated bool     // 是否为生成列
+	Affinity    string   // SQLite的类型亲和性
+	GoType      string   // 方言确定的Go类型，为空时由生成器按类型名推断
+}
 
-	rows, err := d.db.Query(query)
+// GetTableList 获取数据库中的所有表和视图的名称，支持模式的数据库的表名带有模式前缀
+func (d *Database) GetTableList(ctx context.Context) ([]string, error) {
+	tables, err := d.GetTables(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var tables []string
-	for rows.Next() {
-		var tableName string
-		err := rows.Scan(&tableName)
-		if err != nil {
-			return nil, err
-		}
-		tables = append(tables, tableName)
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		names[i] = t.QualifiedName()
 	}
-
-	return tables, nil
+	return names, nil
 }
 
-// getSQLiteTableList 获取SQLite数据库中的所有表
-func (d *Database) getSQLiteTableList() ([]string, error) {
-	query := `
-		SELECT 
-			name 
-		FROM 
-			sqlite_master 
-		WHERE 
-			type='table' 
-			AND name NOT LIKE 'sqlite_%' 
-		ORDER BY 
-			name
-	`
+// GetTables 获取数据库中的所有表和视图
+func (d *Database) GetTables(ctx context.Context) ([]TableInfo, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
 
-	rows, err := d.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var tables []string
-	for rows.Next() {
-		var tableName string
-		err := rows.Scan(&tableName)
-		if err != nil {
-			return nil, err
-		}
-		tables = append(tables, tableName)
-	}
-
-	return tables, nil
-}
\ No newline at end of file
+	return d.dialect.Tables(ctx, d)
+}
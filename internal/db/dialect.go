@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect 数据库方言，封装各数据库在连接、元数据查询、SQL语法和类型映射上的差异
+// 新增数据库时实现该接口并通过 RegisterDialect 注册，一般嵌入 BaseDialect 只覆盖不同之处
+type Dialect interface {
+	// Name 方言名，即配置和命令行中使用的数据库类型
+	Name() string
+	// DriverName database/sql 的驱动名
+	DriverName() string
+	// Aliases 方言的别名，如 sqlite 之于 sqlite3
+	Aliases() []string
+	// SupportsSchema 表名是否可以带模式前缀
+	SupportsSchema() bool
+	// DSN 将结构化的连接参数转换为驱动的连接字符串
+	DSN(p ConnParams) (string, error)
+	// ConnFields 返回该方言使用的连接参数字段，界面据此显示输入项
+	ConnFields() []string
+
+	// Tables 列出数据库中的所有表和视图
+	Tables(ctx context.Context, d *Database) ([]TableInfo, error)
+	// Columns 获取表的列信息，schema为空时使用默认模式
+	Columns(ctx context.Context, d *Database, schema, table string) ([]ColumnInfo, error)
+	// ForeignKeys 获取表的外键
+	ForeignKeys(ctx context.Context, d *Database, schema, table string) ([]ForeignKey, error)
+	// Indexes 获取表的索引
+	Indexes(ctx context.Context, d *Database, schema, table string) ([]IndexInfo, error)
+	// TableExists 检查表是否存在
+	TableExists(ctx context.Context, d *Database, schema, table string) (bool, error)
+
+	// QuoteIdent 引用标识符
+	QuoteIdent(name string) string
+	// Placeholder 返回第n个(从1开始)参数占位符
+	Placeholder(n int) string
+	// MaxParams 单条语句允许的最大参数个数
+	MaxParams() int
+	// MaxRows 单条 INSERT 语句允许的最大行数，0表示不限制
+	MaxRows() int
+	// LimitOffset 返回追加在查询末尾的分页子句，orderBy表示查询是否已带有ORDER BY
+	LimitOffset(limit, offset int, orderBy bool) string
+	// QuoteString 返回字符串字面量
+	QuoteString(s string) string
+	// BytesLiteral 返回二进制字面量
+	BytesLiteral(b []byte) string
+	// BoolLiteral 返回布尔字面量
+	BoolLiteral(b bool) string
+
+	// GoType 返回方言特有类型对应的Go类型，返回空字符串时由生成器按类型名推断
+	GoType(col ColumnInfo) string
+	// ColumnType 返回值类别在该方言中的建表列类型
+	ColumnType(kind Kind, isPrimary bool) string
+}
+
+// dialects 已注册的方言，键为方言名和别名
+var dialects = map[string]Dialect{}
+
+// RegisterDialect 注册方言，名称或别名重复时panic
+func RegisterDialect(dialect Dialect) {
+	for _, name := range append([]string{dialect.Name()}, dialect.Aliases()...) {
+		if _, ok := dialects[name]; ok {
+			panic(fmt.Sprintf("数据库方言 %s 重复注册", name))
+		}
+		dialects[name] = dialect
+	}
+}
+
+// LookupDialect 按方言名或别名查找方言，不区分大小写
+func LookupDialect(name string) (Dialect, error) {
+	dialect, ok := dialects[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("不支持的数据库类型: %s", name)
+	}
+	return dialect, nil
+}
+
+// DialectNames 返回所有已注册方言的名称，不含别名
+func DialectNames() []string {
+	var names []string
+	for name, dialect := range dialects {
+		if name == dialect.Name() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Literal 将值格式化为指定方言的SQL字面量
+func Literal(dialect Dialect, v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case bool:
+		return dialect.BoolLiteral(val)
+	case time.Time:
+		return dialect.QuoteString(val.Format("2006-01-02 15:04:05.999999"))
+	case []byte:
+		return dialect.BytesLiteral(val)
+	case string:
+		return dialect.QuoteString(val)
+	default:
+		return dialect.QuoteString(fmt.Sprint(val))
+	}
+}
+
+// BaseDialect 方言的通用实现，使用标准SQL语法
+type BaseDialect struct{}
+
+// Aliases 默认没有别名
+func (BaseDialect) Aliases() []string {
+	return nil
+}
+
+// SupportsSchema 默认不支持模式前缀
+func (BaseDialect) SupportsSchema() bool {
+	return false
+}
+
+// TableExists 在表列表中查找表
+func (BaseDialect) TableExists(ctx context.Context, d *Database, schema, table string) (bool, error) {
+	tables, err := d.GetTables(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, t := range tables {
+		if t.Name == table && (schema == "" || t.Schema == schema) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// QuoteIdent 使用双引号引用标识符
+func (BaseDialect) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Placeholder 使用问号占位符
+func (BaseDialect) Placeholder(n int) string {
+	return "?"
+}
+
+// MaxParams 默认最多65535个参数
+func (BaseDialect) MaxParams() int {
+	return 65535
+}
+
+// MaxRows 默认不限制行数，只受参数个数限制
+func (BaseDialect) MaxRows() int {
+	return 0
+}
+
+// LimitOffset 使用 LIMIT ... OFFSET ... 分页
+func (BaseDialect) LimitOffset(limit, offset int, orderBy bool) string {
+	var clause string
+	if limit > 0 {
+		clause += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	if offset > 0 {
+		clause += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return clause
+}
+
+// QuoteString 使用单引号引用字符串，单引号写作两个单引号
+func (BaseDialect) QuoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// BytesLiteral 使用 X'...' 形式的十六进制字面量
+func (BaseDialect) BytesLiteral(b []byte) string {
+	return fmt.Sprintf("X'%s'", hex.EncodeToString(b))
+}
+
+// BoolLiteral 使用 1 和 0 表示布尔值
+func (BaseDialect) BoolLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// GoType 默认由生成器按类型名推断
+func (BaseDialect) GoType(col ColumnInfo) string {
+	return ""
+}
+
+// ColumnType 使用通用的列类型
+func (BaseDialect) ColumnType(kind Kind, isPrimary bool) string {
+	switch kind {
+	case KindInt:
+		return "INTEGER"
+	case KindFloat:
+		return "REAL"
+	case KindDecimal:
+		return "NUMERIC"
+	case KindBool:
+		return "BOOLEAN"
+	case KindTime:
+		return "TIMESTAMP"
+	case KindBytes:
+		return "BLOB"
+	default:
+		return "TEXT"
+	}
+}
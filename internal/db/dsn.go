@@ -0,0 +1,67 @@
+package db
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// 连接参数字段名，与配置文件中的键名一致，用于 Dialect.ConnFields
+const (
+	FieldHost     = "host"
+	FieldPort     = "port"
+	FieldUser     = "user"
+	FieldPassword = "password"
+	FieldDatabase = "database"
+	FieldParams   = "params"
+	FieldSSLMode  = "sslmode"
+	FieldSocket   = "socket"
+	FieldFile     = "file"
+)
+
+// ConnParams 结构化的连接参数，由各方言转换为对应驱动的连接字符串
+type ConnParams struct {
+	Host     string
+	Port     int // 0表示使用驱动的默认端口
+	User     string
+	Password string
+	Database string
+	Params   map[string]string // 附加的驱动参数
+	SSLMode  string            // PostgreSQL的sslmode
+	Socket   string            // MySQL的Unix套接字路径，设置后忽略主机和端口
+	File     string            // SQLite数据库文件路径
+}
+
+// hostPort 拼接主机和端口，端口为0时只返回主机
+func (p ConnParams) hostPort() string {
+	if p.Port == 0 {
+		return p.Host
+	}
+	return net.JoinHostPort(p.Host, strconv.Itoa(p.Port))
+}
+
+// userInfo 返回URL中的用户名和密码，未设置用户名时返回nil
+func (p ConnParams) userInfo() *url.Userinfo {
+	if p.User == "" {
+		return nil
+	}
+	if p.Password == "" {
+		return url.User(p.User)
+	}
+	return url.UserPassword(p.User, p.Password)
+}
+
+// query 将附加参数编码为URL查询字符串，键按字母排序
+func (p ConnParams) query() url.Values {
+	values := url.Values{}
+	keys := make([]string, 0, len(p.Params))
+	for k := range p.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		values.Set(k, p.Params[k])
+	}
+	return values
+}
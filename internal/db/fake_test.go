@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeResult 模拟驱动对一条查询返回的结果
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeQuery 模拟驱动收到的查询
+type fakeQuery struct {
+	query string
+	args  []interface{}
+}
+
+// fakeConnector 按顺序返回预设结果的模拟驱动，并记录收到的查询
+type fakeConnector struct {
+	results []fakeResult
+	queries []fakeQuery
+}
+
+// newFakeDatabase 创建使用模拟驱动的Database
+func newFakeDatabase(t *testing.T, dbType string, results ...fakeResult) (*Database, *fakeConnector) {
+	t.Helper()
+	connector := &fakeConnector{results: results}
+	database, err := NewDatabase(sql.OpenDB(connector), dbType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database, connector
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("请使用 fakeConnector")
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("模拟驱动不支持预处理语句")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("模拟驱动不支持事务")
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.connector.queries = append(c.connector.queries, fakeQuery{query: query, args: values})
+
+	if len(c.connector.results) == 0 {
+		return &fakeRows{}, nil
+	}
+	result := c.connector.results[0]
+	c.connector.results = c.connector.results[1:]
+	return &fakeRows{columns: result.columns, rows: result.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ForeignKey 外键信息
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// GetForeignKeys 获取表的外键
+func (d *Database) GetForeignKeys(ctx context.Context, tableName string) ([]ForeignKey, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	schema, table := d.SplitTableName(tableName)
+	return d.dialect.ForeignKeys(ctx, d, schema, table)
+}
+
+// scanForeignKeys 按约束名将逐列的外键记录合并
+func scanForeignKeys(rows *sql.Rows) ([]ForeignKey, error) {
+	defer rows.Close()
+
+	var keys []ForeignKey
+	for rows.Next() {
+		var name, column, refTable, refColumn string
+		err := rows.Scan(&name, &column, &refTable, &refColumn)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(keys) == 0 || keys[len(keys)-1].Name != name {
+			keys = append(keys, ForeignKey{
+				Name:     name,
+				RefTable: refTable,
+			})
+		}
+		fk := &keys[len(keys)-1]
+		fk.Columns = append(fk.Columns, column)
+		fk.RefColumns = append(fk.RefColumns, refColumn)
+	}
+
+	return keys, rows.Err()
+}
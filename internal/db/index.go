@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// IndexInfo 索引信息
+type IndexInfo struct {
+	Name      string
+	Columns   []string
+	IsUnique  bool
+	IsPrimary bool
+}
+
+// GetIndexes 获取表的索引
+func (d *Database) GetIndexes(ctx context.Context, tableName string) ([]IndexInfo, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	schema, table := d.SplitTableName(tableName)
+	return d.dialect.Indexes(ctx, d, schema, table)
+}
+
+// scanIndexes 按索引名将逐列的索引记录合并
+func scanIndexes(rows *sql.Rows) ([]IndexInfo, error) {
+	defer rows.Close()
+
+	var indexes []IndexInfo
+	for rows.Next() {
+		var name, column string
+		var unique, primary bool
+		err := rows.Scan(&name, &column, &unique, &primary)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, IndexInfo{
+				Name:      name,
+				IsUnique:  unique,
+				IsPrimary: primary,
+			})
+		}
+		idx := &indexes[len(indexes)-1]
+		idx.Columns = append(idx.Columns, column)
+	}
+
+	return indexes, rows.Err()
+}
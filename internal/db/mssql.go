@@ -0,0 +1,326 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	_ "github.com/microsoft/go-mssqldb"
+)
+
+func init() {
+	RegisterDialect(mssqlDialect{})
+}
+
+// mssqlDialect SQL Server方言
+type mssqlDialect struct {
+	BaseDialect
+}
+
+// Name 方言名
+func (mssqlDialect) Name() string {
+	return "mssql"
+}
+
+// DriverName 驱动名，mssql 驱动对应旧的参数语法，使用支持 @p1 形式参数的 sqlserver 驱动
+func (mssqlDialect) DriverName() string {
+	return "sqlserver"
+}
+
+// Aliases 别名
+func (mssqlDialect) Aliases() []string {
+	return []string{"sqlserver"}
+}
+
+// SupportsSchema 支持模式前缀
+func (mssqlDialect) SupportsSchema() bool {
+	return true
+}
+
+// DSN 生成 sqlserver:// 形式的连接字符串，数据库名作为 database 参数
+func (mssqlDialect) DSN(p ConnParams) (string, error) {
+	query := p.query()
+	if p.Database != "" {
+		query.Set("database", p.Database)
+	}
+
+	u := url.URL{
+		Scheme:   "sqlserver",
+		User:     p.userInfo(),
+		Host:     p.hostPort(),
+		RawQuery: query.Encode(),
+	}
+	return u.String(), nil
+}
+
+// ConnFields SQL Server使用的连接参数
+func (mssqlDialect) ConnFields() []string {
+	return []string{FieldHost, FieldPort, FieldUser, FieldPassword, FieldDatabase, FieldParams}
+}
+
+// TableExists 按对象ID检查表是否存在
+func (mssqlDialect) TableExists(ctx context.Context, d *Database, schema, table string) (bool, error) {
+	var exists bool
+	query := "SELECT CAST(CASE WHEN OBJECT_ID(@p1) IS NULL THEN 0 ELSE 1 END AS BIT)"
+	err := d.db.QueryRowContext(ctx, query, d.quoteTable(schema, table)).Scan(&exists)
+	return exists, err
+}
+
+// QuoteIdent 使用方括号引用标识符
+func (mssqlDialect) QuoteIdent(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+// Placeholder 使用 @pn 占位符
+func (mssqlDialect) Placeholder(n int) string {
+	return fmt.Sprintf("@p%d", n)
+}
+
+// MaxParams SQL Server单条语句最多2100个参数
+func (mssqlDialect) MaxParams() int {
+	return 2000
+}
+
+// MaxRows SQL Server的 VALUES 子句最多1000行
+func (mssqlDialect) MaxRows() int {
+	return 1000
+}
+
+// LimitOffset 使用 OFFSET ... FETCH 分页，且必须带有ORDER BY
+func (mssqlDialect) LimitOffset(limit, offset int, orderBy bool) string {
+	if limit <= 0 && offset <= 0 {
+		return ""
+	}
+
+	var clause string
+	if !orderBy {
+		clause = " ORDER BY (SELECT NULL)"
+	}
+	clause += fmt.Sprintf(" OFFSET %d ROWS", offset)
+	if limit > 0 {
+		clause += fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", limit)
+	}
+	return clause
+}
+
+// QuoteString 使用Unicode字符串字面量，避免非ASCII字符按代码页转换
+func (mssqlDialect) QuoteString(s string) string {
+	return "N" + BaseDialect{}.QuoteString(s)
+}
+
+// BytesLiteral 使用 0x... 形式的二进制字面量
+func (mssqlDialect) BytesLiteral(b []byte) string {
+	return fmt.Sprintf("0x%x", b)
+}
+
+// GoType 映射SQL Server特有的类型
+func (mssqlDialect) GoType(col ColumnInfo) string {
+	switch strings.ToLower(col.Type) {
+	case "bit":
+		return "bool"
+	case "rowversion", "image":
+		return "[]byte"
+	case "xml":
+		return "string"
+	case "sql_variant":
+		return "interface{}"
+	default:
+		return ""
+	}
+}
+
+// ColumnType 返回SQL Server的列类型
+func (mssqlDialect) ColumnType(kind Kind, isPrimary bool) string {
+	switch kind {
+	case KindInt:
+		return "BIGINT"
+	case KindFloat:
+		return "FLOAT"
+	case KindDecimal:
+		return "DECIMAL(38,10)"
+	case KindBool:
+		return "BIT"
+	case KindTime:
+		return "DATETIME2"
+	case KindBytes:
+		return "VARBINARY(MAX)"
+	case KindUUID:
+		return "UNIQUEIDENTIFIER"
+	default:
+		// 索引键长度有限，主键列不能使用MAX类型
+		if isPrimary {
+			return "NVARCHAR(450)"
+		}
+		return "NVARCHAR(MAX)"
+	}
+}
+
+// Columns 获取SQL Server表结构，列注释取自扩展属性 MS_Description
+func (mssqlDialect) Columns(ctx context.Context, d *Database, schema, table string) ([]ColumnInfo, error) {
+	query := `
+		SELECT
+			c.COLUMN_NAME,
+			CASE c.DATA_TYPE WHEN 'timestamp' THEN 'rowversion' ELSE c.DATA_TYPE END,
+			c.IS_NULLABLE,
+			COALESCE(k.ORDINAL_POSITION, 0),
+			COALESCE(CAST(ep.value AS NVARCHAR(4000)), ''),
+			c.COLUMN_DEFAULT,
+			COALESCE(COLUMNPROPERTY(` + mssqlObjectID + `, c.COLUMN_NAME, 'IsComputed'), 0)
+		FROM
+			INFORMATION_SCHEMA.COLUMNS c
+		LEFT JOIN
+			INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc ON tc.TABLE_SCHEMA = c.TABLE_SCHEMA
+				AND tc.TABLE_NAME = c.TABLE_NAME AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+		LEFT JOIN
+			INFORMATION_SCHEMA.KEY_COLUMN_USAGE k ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+				AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME AND k.COLUMN_NAME = c.COLUMN_NAME
+		LEFT JOIN
+			sys.extended_properties ep ON ep.class = 1 AND ep.name = 'MS_Description'
+				AND ep.major_id = ` + mssqlObjectID + `
+				AND ep.minor_id = COLUMNPROPERTY(` + mssqlObjectID + `, c.COLUMN_NAME, 'ColumnId')
+		WHERE
+			c.TABLE_SCHEMA = COALESCE(NULLIF(@p1, ''), SCHEMA_NAME())
+			AND c.TABLE_NAME = @p2
+		ORDER BY
+			c.ORDINAL_POSITION
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var col ColumnInfo
+		var isNullable string
+		var dflt sql.NullString
+		var computed int
+		err := rows.Scan(&col.Name, &col.Type, &isNullable, &col.PKOrdinal, &col.Comment, &dflt, &computed)
+		if err != nil {
+			return nil, err
+		}
+
+		col.IsNullable = isNullable == "YES"
+		col.IsPrimary = col.PKOrdinal > 0
+		col.IsGenerated = computed == 1
+		if dflt.Valid {
+			col.Default = &dflt.String
+		}
+		columns = append(columns, col)
+	}
+
+	return columns, rows.Err()
+}
+
+// Tables 获取SQL Server数据库中的所有表和视图，表注释取自扩展属性 MS_Description
+func (mssqlDialect) Tables(ctx context.Context, d *Database) ([]TableInfo, error) {
+	query := `
+		SELECT
+			c.TABLE_SCHEMA,
+			c.TABLE_NAME,
+			c.TABLE_TYPE,
+			COALESCE(CAST(ep.value AS NVARCHAR(4000)), '')
+		FROM
+			INFORMATION_SCHEMA.TABLES c
+		LEFT JOIN
+			sys.extended_properties ep ON ep.class = 1 AND ep.name = 'MS_Description'
+				AND ep.major_id = ` + mssqlObjectID + ` AND ep.minor_id = 0
+		WHERE
+			(@p1 = '' OR c.TABLE_SCHEMA = @p1)
+		ORDER BY
+			c.TABLE_SCHEMA, c.TABLE_NAME
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, d.schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	for rows.Next() {
+		var t TableInfo
+		var tableType string
+		err := rows.Scan(&t.Schema, &t.Name, &tableType, &t.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Type = TableTypeTable
+		if tableType == "VIEW" {
+			t.Type = TableTypeView
+		}
+		tables = append(tables, t)
+	}
+
+	return tables, rows.Err()
+}
+
+// ForeignKeys 获取SQL Server表的外键
+func (mssqlDialect) ForeignKeys(ctx context.Context, d *Database, schema, table string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			fk.name,
+			pc.name,
+			SCHEMA_NAME(rt.schema_id) + '.' + rt.name,
+			rc.name
+		FROM
+			sys.foreign_keys fk
+		JOIN
+			sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+		JOIN
+			sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+		JOIN
+			sys.objects rt ON rt.object_id = fkc.referenced_object_id
+		JOIN
+			sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+		WHERE
+			fk.parent_object_id = OBJECT_ID(@p1)
+		ORDER BY
+			fk.name, fkc.constraint_column_id
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, d.quoteTable(schema, table))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanForeignKeys(rows)
+}
+
+// Indexes 获取SQL Server表的索引，不含INCLUDE列
+func (mssqlDialect) Indexes(ctx context.Context, d *Database, schema, table string) ([]IndexInfo, error) {
+	query := `
+		SELECT
+			i.name,
+			c.name,
+			i.is_unique,
+			i.is_primary_key
+		FROM
+			sys.indexes i
+		JOIN
+			sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		JOIN
+			sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE
+			i.object_id = OBJECT_ID(@p1)
+			AND i.name IS NOT NULL
+			AND ic.is_included_column = 0
+		ORDER BY
+			i.name, ic.key_ordinal
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, d.quoteTable(schema, table))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+// mssqlObjectID 按INFORMATION_SCHEMA视图(别名c)中的模式名和表名求SQL Server对象ID的表达式
+const mssqlObjectID = `OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME))`
@@ -0,0 +1,284 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func init() {
+	RegisterDialect(mysqlDialect{})
+}
+
+// mysqlDialect MySQL方言，兼容协议的MariaDB和TiDB使用同一实现
+type mysqlDialect struct {
+	BaseDialect
+}
+
+// Name 方言名
+func (mysqlDialect) Name() string {
+	return "mysql"
+}
+
+// DriverName 驱动名
+func (mysqlDialect) DriverName() string {
+	return "mysql"
+}
+
+// Aliases 别名
+func (mysqlDialect) Aliases() []string {
+	return []string{"mariadb", "tidb"}
+}
+
+// DSN 生成 go-sql-driver/mysql 格式的连接字符串，设置了套接字时通过Unix套接字连接
+func (mysqlDialect) DSN(p ConnParams) (string, error) {
+	cfg := mysql.NewConfig()
+	cfg.User = p.User
+	cfg.Passwd = p.Password
+	cfg.DBName = p.Database
+	cfg.Params = p.Params
+	if p.Socket != "" {
+		cfg.Net = "unix"
+		cfg.Addr = p.Socket
+	} else {
+		cfg.Net = "tcp"
+		cfg.Addr = p.hostPort()
+	}
+	return cfg.FormatDSN(), nil
+}
+
+// ConnFields MySQL使用的连接参数
+func (mysqlDialect) ConnFields() []string {
+	return []string{FieldHost, FieldPort, FieldUser, FieldPassword, FieldDatabase, FieldSocket, FieldParams}
+}
+
+// QuoteIdent 使用反引号引用标识符
+func (mysqlDialect) QuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// LimitOffset OFFSET必须与LIMIT同时出现
+func (mysqlDialect) LimitOffset(limit, offset int, orderBy bool) string {
+	if offset > 0 && limit <= 0 {
+		return fmt.Sprintf(" LIMIT 18446744073709551615 OFFSET %d", offset)
+	}
+	return BaseDialect{}.LimitOffset(limit, offset, orderBy)
+}
+
+// QuoteString MySQL默认将反斜杠视为转义符
+func (mysqlDialect) QuoteString(s string) string {
+	return BaseDialect{}.QuoteString(strings.ReplaceAll(s, `\`, `\\`))
+}
+
+// ColumnType 返回MySQL的列类型
+func (mysqlDialect) ColumnType(kind Kind, isPrimary bool) string {
+	switch kind {
+	case KindInt:
+		return "BIGINT"
+	case KindFloat:
+		return "DOUBLE"
+	case KindDecimal:
+		return "DECIMAL(38,10)"
+	case KindBool:
+		return "TINYINT(1)"
+	case KindTime:
+		return "DATETIME(6)"
+	case KindBytes:
+		return "LONGBLOB"
+	default:
+		// MySQL的TEXT列不能直接作为主键
+		if isPrimary {
+			return "VARCHAR(255)"
+		}
+		return "LONGTEXT"
+	}
+}
+
+// Columns 获取MySQL表结构
+func (mysqlDialect) Columns(ctx context.Context, d *Database, schema, table string) ([]ColumnInfo, error) {
+	query := `
+		SELECT 
+			COLUMN_NAME, 
+			DATA_TYPE, 
+			IS_NULLABLE, 
+			COLUMN_KEY, 
+			COLUMN_COMMENT,
+			COLUMN_DEFAULT,
+			COLUMN_TYPE
+		FROM 
+			INFORMATION_SCHEMA.COLUMNS 
+		WHERE 
+			TABLE_SCHEMA = DATABASE()
+			AND TABLE_NAME = ? 
+		ORDER BY 
+			ORDINAL_POSITION
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var col ColumnInfo
+		var isNullable, columnKey, columnType string
+		var dflt sql.NullString
+		err := rows.Scan(&col.Name, &col.Type, &isNullable, &columnKey, &col.Comment, &dflt, &columnType)
+		if err != nil {
+			return nil, err
+		}
+
+		col.IsNullable = isNullable == "YES"
+		col.IsPrimary = columnKey == "PRI"
+		if dflt.Valid {
+			col.Default = &dflt.String
+		}
+		// 保留位数，BIT列是位字段而非布尔值
+		if strings.EqualFold(col.Type, "bit") {
+			col.Type = columnType
+		}
+		switch strings.ToLower(col.Type) {
+		case "enum":
+			col.EnumValues = parseMySQLEnumValues(columnType)
+		case "set":
+			col.EnumValues = parseMySQLEnumValues(columnType)
+			col.IsSet = true
+		}
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+// parseMySQLEnumValues 从 enum('a','b') 或 set('a','b') 形式的COLUMN_TYPE中解析取值
+func parseMySQLEnumValues(columnType string) []string {
+	start := strings.Index(columnType, "(")
+	end := strings.LastIndex(columnType, ")")
+	if start < 0 || end < start {
+		return nil
+	}
+
+	var values []string
+	var value strings.Builder
+	inQuote := false
+	list := columnType[start+1 : end]
+	for i := 0; i < len(list); i++ {
+		c := list[i]
+		switch {
+		case !inQuote:
+			if c == '\'' {
+				inQuote = true
+				value.Reset()
+			}
+		case c == '\'' && i+1 < len(list) && list[i+1] == '\'':
+			// 取值中的单引号写作两个单引号
+			value.WriteByte(c)
+			i++
+		case c == '\'':
+			inQuote = false
+			values = append(values, value.String())
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return values
+}
+
+// Tables 获取MySQL数据库中的所有表和视图
+func (mysqlDialect) Tables(ctx context.Context, d *Database) ([]TableInfo, error) {
+	query := `
+		SELECT 
+			TABLE_NAME, 
+			TABLE_TYPE, 
+			TABLE_COMMENT 
+		FROM 
+			INFORMATION_SCHEMA.TABLES 
+		WHERE 
+			TABLE_SCHEMA = DATABASE() 
+		ORDER BY 
+			TABLE_NAME
+	`
+
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	for rows.Next() {
+		var t TableInfo
+		var tableType string
+		err := rows.Scan(&t.Name, &tableType, &t.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Type = TableTypeTable
+		if strings.Contains(tableType, "VIEW") {
+			t.Type = TableTypeView
+			// MySQL视图的注释固定为"VIEW"
+			t.Comment = ""
+		}
+		tables = append(tables, t)
+	}
+
+	return tables, nil
+}
+
+// ForeignKeys 获取MySQL表的外键
+func (mysqlDialect) ForeignKeys(ctx context.Context, d *Database, schema, table string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			CONSTRAINT_NAME,
+			COLUMN_NAME,
+			REFERENCED_TABLE_NAME,
+			REFERENCED_COLUMN_NAME
+		FROM
+			INFORMATION_SCHEMA.KEY_COLUMN_USAGE
+		WHERE
+			TABLE_SCHEMA = DATABASE()
+			AND TABLE_NAME = ?
+			AND REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY
+			CONSTRAINT_NAME, ORDINAL_POSITION
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanForeignKeys(rows)
+}
+
+// Indexes 获取MySQL表的索引
+func (mysqlDialect) Indexes(ctx context.Context, d *Database, schema, table string) ([]IndexInfo, error) {
+	query := `
+		SELECT
+			INDEX_NAME,
+			COALESCE(COLUMN_NAME, ''),
+			NON_UNIQUE = 0,
+			INDEX_NAME = 'PRIMARY'
+		FROM
+			INFORMATION_SCHEMA.STATISTICS
+		WHERE
+			TABLE_SCHEMA = DATABASE()
+			AND TABLE_NAME = ?
+		ORDER BY
+			INDEX_NAME, SEQ_IN_INDEX
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
@@ -0,0 +1,311 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+func init() {
+	RegisterDialect(postgresDialect{})
+}
+
+// postgresDialect PostgreSQL方言
+type postgresDialect struct {
+	BaseDialect
+}
+
+// Name 方言名
+func (postgresDialect) Name() string {
+	return "postgres"
+}
+
+// DriverName 驱动名
+func (postgresDialect) DriverName() string {
+	return "postgres"
+}
+
+// Aliases 别名
+func (postgresDialect) Aliases() []string {
+	return []string{"postgresql", "pg"}
+}
+
+// SupportsSchema 支持模式前缀
+func (postgresDialect) SupportsSchema() bool {
+	return true
+}
+
+// DSN 生成 postgres:// 形式的连接字符串
+func (postgresDialect) DSN(p ConnParams) (string, error) {
+	query := p.query()
+	if p.SSLMode != "" {
+		query.Set("sslmode", p.SSLMode)
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     p.userInfo(),
+		Host:     p.hostPort(),
+		RawQuery: query.Encode(),
+	}
+	if p.Database != "" {
+		u.Path = "/" + p.Database
+	}
+	return u.String(), nil
+}
+
+// ConnFields PostgreSQL使用的连接参数
+func (postgresDialect) ConnFields() []string {
+	return []string{FieldHost, FieldPort, FieldUser, FieldPassword, FieldDatabase, FieldSSLMode, FieldParams}
+}
+
+// TableExists 按模式和表名查找关系
+func (postgresDialect) TableExists(ctx context.Context, d *Database, schema, table string) (bool, error) {
+	var exists bool
+	err := d.db.QueryRowContext(ctx, "SELECT "+pgRelationOID+" IS NOT NULL", schema, table).Scan(&exists)
+	return exists, err
+}
+
+// Placeholder 使用 $n 占位符
+func (postgresDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// BytesLiteral 使用decode函数构造bytea值
+func (postgresDialect) BytesLiteral(b []byte) string {
+	return fmt.Sprintf("decode('%s', 'hex')", hex.EncodeToString(b))
+}
+
+// BoolLiteral 使用 TRUE 和 FALSE
+func (postgresDialect) BoolLiteral(b bool) string {
+	return strings.ToUpper(fmt.Sprint(b))
+}
+
+// ColumnType 返回PostgreSQL的列类型
+func (postgresDialect) ColumnType(kind Kind, isPrimary bool) string {
+	switch kind {
+	case KindInt:
+		return "BIGINT"
+	case KindFloat:
+		return "DOUBLE PRECISION"
+	case KindDecimal:
+		return "NUMERIC"
+	case KindBool:
+		return "BOOLEAN"
+	case KindTime:
+		return "TIMESTAMP"
+	case KindBytes:
+		return "BYTEA"
+	case KindUUID:
+		return "UUID"
+	default:
+		return "TEXT"
+	}
+}
+
+// Columns 获取PostgreSQL表结构
+func (postgresDialect) Columns(ctx context.Context, d *Database, schema, table string) ([]ColumnInfo, error) {
+	query := `
+		SELECT 
+			a.attname AS column_name,
+			format_type(a.atttypid, a.atttypmod) AS data_type,
+			CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END AS is_nullable,
+			CASE WHEN p.contype = 'p' THEN 'PRI' ELSE '' END AS column_key,
+			COALESCE(pg_catalog.col_description(a.attrelid, a.attnum), '') AS column_comment,
+			pg_catalog.pg_get_expr(ad.adbin, ad.adrelid) AS column_default,
+			dt.typname AS udt_name,
+			CASE WHEN dt.typtype = 'd' THEN format_type(dt.typbasetype, dt.typtypmod) ELSE '' END AS base_type,
+			t.typcategory = 'A' AS is_array,
+			COALESCE(format_type(et.oid, NULL), '') AS elem_type,
+			CASE WHEN COALESCE(et.typtype, t.typtype) = 'e' THEN COALESCE(et.typname, t.typname) ELSE '' END AS enum_name,
+			COALESCE((
+				SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder)
+				FROM pg_catalog.pg_enum e
+				WHERE e.enumtypid = COALESCE(et.oid, t.oid)
+			)::text, '[]') AS enum_values
+		FROM 
+			pg_catalog.pg_attribute a
+		JOIN 
+			pg_catalog.pg_type dt ON dt.oid = a.atttypid
+		JOIN 
+			pg_catalog.pg_type t ON t.oid = CASE WHEN dt.typtype = 'd' THEN dt.typbasetype ELSE dt.oid END
+		LEFT JOIN 
+			pg_catalog.pg_type et ON et.oid = t.typelem AND t.typcategory = 'A'
+		LEFT JOIN 
+			pg_catalog.pg_constraint p ON p.conrelid = a.attrelid AND p.contype = 'p' AND a.attnum = ANY(p.conkey)
+		LEFT JOIN 
+			pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+		WHERE 
+			a.attrelid = ` + pgRelationOID + `
+			AND a.attnum > 0
+			AND NOT a.attisdropped
+		ORDER BY 
+			a.attnum
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var col ColumnInfo
+		var isNullable, columnKey string
+		var dflt sql.NullString
+		var enumValues string
+		err := rows.Scan(&col.Name, &col.Type, &isNullable, &columnKey, &col.Comment, &dflt,
+			&col.UDTName, &col.BaseType, &col.IsArray, &col.ElemType, &col.EnumName, &enumValues)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(enumValues), &col.EnumValues)
+		if err != nil {
+			return nil, err
+		}
+
+		col.IsNullable = isNullable == "YES"
+		col.IsPrimary = columnKey == "PRI"
+		if dflt.Valid {
+			col.Default = &dflt.String
+		}
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+// Tables 获取PostgreSQL数据库中的所有表、视图和物化视图
+func (postgresDialect) Tables(ctx context.Context, d *Database) ([]TableInfo, error) {
+	query := `
+		SELECT 
+			n.nspname, 
+			c.relname, 
+			c.relkind, 
+			COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '') 
+		FROM 
+			pg_catalog.pg_class c 
+		JOIN 
+			pg_catalog.pg_namespace n ON n.oid = c.relnamespace 
+		WHERE 
+			c.relkind IN ('r', 'p', 'v', 'm') 
+			AND n.nspname NOT IN ('pg_catalog', 'information_schema') 
+			AND n.nspname NOT LIKE 'pg_toast%' 
+			AND ($1 = '' OR n.nspname = $1) 
+		ORDER BY 
+			n.nspname, c.relname
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, d.schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	for rows.Next() {
+		var t TableInfo
+		var relKind string
+		err := rows.Scan(&t.Schema, &t.Name, &relKind, &t.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		switch relKind {
+		case "v":
+			t.Type = TableTypeView
+		case "m":
+			t.Type = TableTypeMaterializedView
+		default:
+			t.Type = TableTypeTable
+		}
+		tables = append(tables, t)
+	}
+
+	return tables, nil
+}
+
+// ForeignKeys 获取PostgreSQL表的外键
+func (postgresDialect) ForeignKeys(ctx context.Context, d *Database, schema, table string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			c.conname,
+			a.attname,
+			nf.nspname || '.' || cf.relname,
+			af.attname
+		FROM
+			pg_catalog.pg_constraint c
+		CROSS JOIN LATERAL
+			unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord)
+		JOIN
+			pg_catalog.pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		JOIN
+			pg_catalog.pg_class cf ON cf.oid = c.confrelid
+		JOIN
+			pg_catalog.pg_namespace nf ON nf.oid = cf.relnamespace
+		JOIN
+			pg_catalog.pg_attribute af ON af.attrelid = c.confrelid AND af.attnum = k.fattnum
+		WHERE
+			c.contype = 'f'
+			AND c.conrelid = ` + pgRelationOID + `
+		ORDER BY
+			c.conname, k.ord
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanForeignKeys(rows)
+}
+
+// Indexes 获取PostgreSQL表的索引
+func (postgresDialect) Indexes(ctx context.Context, d *Database, schema, table string) ([]IndexInfo, error) {
+	query := `
+		SELECT
+			i.relname,
+			COALESCE(a.attname, '(expression)'),
+			ix.indisunique,
+			ix.indisprimary
+		FROM
+			pg_catalog.pg_index ix
+		JOIN
+			pg_catalog.pg_class i ON i.oid = ix.indexrelid
+		CROSS JOIN LATERAL
+			unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+		LEFT JOIN
+			pg_catalog.pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = k.attnum
+		WHERE
+			ix.indrelid = ` + pgRelationOID + `
+		ORDER BY
+			i.relname, k.ord
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+// pgRelationOID 按模式名($1)和表名($2)查找关系OID的表达式，找不到时为NULL
+// 模式名为空时与未限定模式的表名相同，按 search_path 中的顺序在各模式中查找
+const pgRelationOID = `(
+	CASE WHEN $1::text = '' THEN to_regclass(quote_ident($2::text))::oid
+	ELSE (
+		SELECT c.oid
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1::text AND c.relname = $2::text
+	) END
+)`
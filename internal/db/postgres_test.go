@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPostgresTableExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string // SetSchema 设置的默认模式
+		table    string
+		wantArgs []interface{}
+	}{
+		{name: "未限定模式时按search_path查找", table: "users", wantArgs: []interface{}{"", "users"}},
+		{name: "限定模式", table: "sales.users", wantArgs: []interface{}{"sales", "users"}},
+		{name: "默认模式", schema: "audit", table: "users", wantArgs: []interface{}{"audit", "users"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, connector := newFakeDatabase(t, "postgres", fakeResult{
+				columns: []string{"exists"},
+				rows:    [][]driver.Value{{true}},
+			})
+			database.SetSchema(tt.schema)
+
+			exists, err := database.TableExists(context.Background(), tt.table)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !exists {
+				t.Error("TableExists() = false，期望 true")
+			}
+
+			query := connector.queries[0]
+			if !reflect.DeepEqual(query.args, tt.wantArgs) {
+				t.Errorf("查询参数 = %v，期望 %v", query.args, tt.wantArgs)
+			}
+			// 未限定模式的表名交给 to_regclass 按 search_path 解析，而不是只查当前模式
+			if !strings.Contains(query.query, "to_regclass(quote_ident($2::text))") || strings.Contains(query.query, "current_schema()") {
+				t.Errorf("查询没有按 search_path 查找未限定模式的表:\n%s", query.query)
+			}
+		})
+	}
+}
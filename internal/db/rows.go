@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Type 返回数据库类型，即方言名
+func (d *Database) Type() string {
+	return d.dialect.Name()
+}
+
+// Dialect 返回数据库方言
+func (d *Database) Dialect() Dialect {
+	return d.dialect
+}
+
+// DB 返回底层的数据库连接
+func (d *Database) DB() *sql.DB {
+	return d.db
+}
+
+// QuoteIdent 按数据库方言引用标识符
+func (d *Database) QuoteIdent(name string) string {
+	return d.dialect.QuoteIdent(name)
+}
+
+// QuoteIdent 按指定的数据库方言引用标识符，未知方言使用双引号
+func QuoteIdent(dbType, name string) string {
+	dialect, err := LookupDialect(dbType)
+	if err != nil {
+		return BaseDialect{}.QuoteIdent(name)
+	}
+	return dialect.QuoteIdent(name)
+}
+
+// Placeholder 返回第n个(从1开始)参数占位符
+func (d *Database) Placeholder(n int) string {
+	return d.dialect.Placeholder(n)
+}
+
+// MaxParams 返回单条语句允许的最大参数个数
+func (d *Database) MaxParams() int {
+	return d.dialect.MaxParams()
+}
+
+// MaxRows 返回单条 INSERT 语句允许的最大行数，0表示不限制
+func (d *Database) MaxRows() int {
+	return d.dialect.MaxRows()
+}
+
+// TableExists 检查表是否存在
+func (d *Database) TableExists(ctx context.Context, tableName string) (bool, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	schema, table := d.SplitTableName(tableName)
+	return d.dialect.TableExists(ctx, d, schema, table)
+}
+
+// SelectQuery 查询参数
+type SelectQuery struct {
+	Table   string
+	Columns []string
+	Where   string
+	OrderBy []string
+	Limit   int
+	Offset  int
+}
+
+// Select 按查询参数读取表数据
+func (d *Database) Select(ctx context.Context, q SelectQuery) (*sql.Rows, error) {
+	cols := "*"
+	if len(q.Columns) > 0 {
+		quoted := make([]string, len(q.Columns))
+		for i, c := range q.Columns {
+			quoted[i] = d.QuoteIdent(c)
+		}
+		cols = strings.Join(quoted, ", ")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", cols, d.QuoteTable(q.Table))
+	if q.Where != "" {
+		query += " WHERE " + q.Where
+	}
+	if len(q.OrderBy) > 0 {
+		quoted := make([]string, len(q.OrderBy))
+		for i, c := range q.OrderBy {
+			quoted[i] = d.QuoteIdent(c)
+		}
+		query += " ORDER BY " + strings.Join(quoted, ", ")
+	}
+	query += d.dialect.LimitOffset(q.Limit, q.Offset, len(q.OrderBy) > 0)
+
+	return d.db.QueryContext(ctx, query)
+}
+
+// FilterTables 按通配符模式筛选表名，include为空时保留全部表，exclude优先
+func FilterTables(tables, include, exclude []string) ([]string, error) {
+	var result []string
+	for _, table := range tables {
+		matched := len(include) == 0
+		for _, pattern := range include {
+			ok, err := path.Match(pattern, table)
+			if err != nil {
+				return nil, fmt.Errorf("无效的匹配模式 %s: %v", pattern, err)
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+
+		for _, pattern := range exclude {
+			ok, err := path.Match(pattern, table)
+			if err != nil {
+				return nil, fmt.Errorf("无效的匹配模式 %s: %v", pattern, err)
+			}
+			if ok {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			result = append(result, table)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterTables(t *testing.T) {
+	tables := []string{"users", "user_roles", "orders", "order_items", "sales.orders", "tmp_import"}
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "全部", want: tables},
+		{name: "通配符", include: []string{"user*"}, want: []string{"users", "user_roles"}},
+		{name: "多个模式", include: []string{"users", "order?"}, want: []string{"users", "orders"}},
+		{name: "排除优先", include: []string{"order*"}, exclude: []string{"*_items"}, want: []string{"orders"}},
+		{name: "只排除", exclude: []string{"tmp_*", "*.*"}, want: []string{"users", "user_roles", "orders", "order_items"}},
+		{name: "模式前缀", include: []string{"sales.*"}, want: []string{"sales.orders"}},
+		{name: "没有匹配", include: []string{"missing"}, want: nil},
+		{name: "无效的模式", include: []string{"[user"}, wantErr: true},
+		{name: "无效的排除模式", exclude: []string{"[user"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterTables(tables, tt.include, tt.exclude)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("期望返回错误，实际结果 %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterTables() = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
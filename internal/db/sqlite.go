@@ -0,0 +1,311 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func init() {
+	RegisterDialect(sqliteDialect{})
+}
+
+// sqliteDialect SQLite方言
+type sqliteDialect struct {
+	BaseDialect
+}
+
+// Name 方言名
+func (sqliteDialect) Name() string {
+	return "sqlite3"
+}
+
+// DriverName 驱动名
+func (sqliteDialect) DriverName() string {
+	return "sqlite3"
+}
+
+// Aliases 别名
+func (sqliteDialect) Aliases() []string {
+	return []string{"sqlite"}
+}
+
+// DSN 返回数据库文件路径，有附加参数时使用 file: URI 形式
+func (sqliteDialect) DSN(p ConnParams) (string, error) {
+	if p.File == "" {
+		return "", fmt.Errorf("未指定SQLite数据库文件")
+	}
+	if len(p.Params) == 0 {
+		return p.File, nil
+	}
+	return "file:" + p.File + "?" + p.query().Encode(), nil
+}
+
+// ConnFields SQLite使用的连接参数
+func (sqliteDialect) ConnFields() []string {
+	return []string{FieldFile, FieldParams}
+}
+
+// MaxParams SQLite默认最多999个参数
+func (sqliteDialect) MaxParams() int {
+	return 999
+}
+
+// LimitOffset OFFSET必须与LIMIT同时出现
+func (sqliteDialect) LimitOffset(limit, offset int, orderBy bool) string {
+	if offset > 0 && limit <= 0 {
+		return fmt.Sprintf(" LIMIT -1 OFFSET %d", offset)
+	}
+	return BaseDialect{}.LimitOffset(limit, offset, orderBy)
+}
+
+// GoType 按类型亲和性映射Go类型
+// NUMERIC亲和性的列再按声明的类型名细分
+func (sqliteDialect) GoType(col ColumnInfo) string {
+	switch col.Affinity {
+	case "INTEGER":
+		return "int64"
+	case "TEXT":
+		return "string"
+	case "REAL":
+		return "float64"
+	case "BLOB":
+		// 未声明类型的列可以存放任意类型的值
+		if strings.TrimSpace(col.Type) == "" {
+			return "interface{}"
+		}
+		return "[]byte"
+	}
+
+	// 认识的类型名(布尔、日期时间、定点数等)由生成器按类型名映射
+	if _, ok := TypeKind(col.Type); ok {
+		return ""
+	}
+	return "float64"
+}
+
+// ColumnType 返回SQLite的列类型
+func (sqliteDialect) ColumnType(kind Kind, isPrimary bool) string {
+	if kind == KindTime {
+		return "DATETIME"
+	}
+	return BaseDialect{}.ColumnType(kind, isPrimary)
+}
+
+// Columns 获取SQLite表结构
+// 使用 table_xinfo 以包含生成列，虚拟表的隐藏列会被忽略
+func (sqliteDialect) Columns(ctx context.Context, d *Database, schema, table string) ([]ColumnInfo, error) {
+	query := fmt.Sprintf("PRAGMA table_xinfo(%s)", d.QuoteIdent(table))
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	pkCount := 0
+	for rows.Next() {
+		var cid int
+		var name, dataType string
+		var notNull, pk, hidden int
+		var dfltValue sql.NullString
+
+		err := rows.Scan(&cid, &name, &dataType, &notNull, &dfltValue, &pk, &hidden)
+		if err != nil {
+			return nil, err
+		}
+
+		// 1 为虚拟表的隐藏列，2、3 分别为 VIRTUAL 和 STORED 生成列
+		if hidden == 1 {
+			continue
+		}
+
+		col := ColumnInfo{
+			Name:        name,
+			Type:        dataType,
+			IsNullable:  notNull == 0,
+			IsPrimary:   pk > 0,
+			PKOrdinal:   pk,
+			Comment:     "",
+			IsGenerated: hidden == 2 || hidden == 3,
+			Affinity:    SQLiteAffinity(dataType),
+		}
+		if dfltValue.Valid {
+			col.Default = &dfltValue.String
+		}
+		if pk > 0 {
+			pkCount++
+		}
+		columns = append(columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 单列的 INTEGER PRIMARY KEY 是rowid的别名，不会为NULL
+	if pkCount == 1 {
+		for i := range columns {
+			if columns[i].IsPrimary && strings.EqualFold(columns[i].Type, "integer") {
+				columns[i].IsNullable = false
+			}
+		}
+	}
+
+	return columns, nil
+}
+
+// SQLiteAffinity 按SQLite文档中的规则确定声明类型的类型亲和性
+func SQLiteAffinity(declType string) string {
+	t := strings.ToUpper(declType)
+	switch {
+	case strings.Contains(t, "INT"):
+		return "INTEGER"
+	case strings.Contains(t, "CHAR") || strings.Contains(t, "CLOB") || strings.Contains(t, "TEXT"):
+		return "TEXT"
+	case t == "" || strings.Contains(t, "BLOB"):
+		return "BLOB"
+	case strings.Contains(t, "REAL") || strings.Contains(t, "FLOA") || strings.Contains(t, "DOUB"):
+		return "REAL"
+	default:
+		return "NUMERIC"
+	}
+}
+
+// Tables 获取SQLite数据库中的所有表和视图
+func (sqliteDialect) Tables(ctx context.Context, d *Database) ([]TableInfo, error) {
+	query := `
+		SELECT 
+			name, 
+			type 
+		FROM 
+			sqlite_master 
+		WHERE 
+			type IN ('table', 'view') 
+			AND name NOT LIKE 'sqlite_%' 
+		ORDER BY 
+			name
+	`
+
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	for rows.Next() {
+		var t TableInfo
+		var tableType string
+		err := rows.Scan(&t.Name, &tableType)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Type = TableTypeTable
+		if tableType == "view" {
+			t.Type = TableTypeView
+		}
+		tables = append(tables, t)
+	}
+
+	return tables, nil
+}
+
+// ForeignKeys 获取SQLite表的外键
+func (sqliteDialect) ForeignKeys(ctx context.Context, d *Database, schema, table string) ([]ForeignKey, error) {
+	query := fmt.Sprintf("PRAGMA foreign_key_list(%s)", d.QuoteIdent(table))
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []ForeignKey
+	index := map[int]int{}
+	for rows.Next() {
+		var id, seq int
+		var refTable, from string
+		var to sql.NullString
+		var onUpdate, onDelete, match string
+
+		err := rows.Scan(&id, &seq, &refTable, &from, &to, &onUpdate, &onDelete, &match)
+		if err != nil {
+			return nil, err
+		}
+
+		i, ok := index[id]
+		if !ok {
+			i = len(keys)
+			index[id] = i
+			keys = append(keys, ForeignKey{
+				Name:     fmt.Sprintf("fk_%s_%d", table, id),
+				RefTable: refTable,
+			})
+		}
+		keys[i].Columns = append(keys[i].Columns, from)
+		// 省略被引用列时引用的是目标表的主键
+		keys[i].RefColumns = append(keys[i].RefColumns, to.String)
+	}
+
+	return keys, rows.Err()
+}
+
+// Indexes 获取SQLite表的索引
+func (sqliteDialect) Indexes(ctx context.Context, d *Database, schema, table string) ([]IndexInfo, error) {
+	query := fmt.Sprintf("PRAGMA index_list(%s)", d.QuoteIdent(table))
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []IndexInfo
+	for rows.Next() {
+		var seq, unique, partial int
+		var name, origin string
+		err := rows.Scan(&seq, &name, &unique, &origin, &partial)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		indexes = append(indexes, IndexInfo{
+			Name:      name,
+			IsUnique:  unique == 1,
+			IsPrimary: origin == "pk",
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 读取完索引列表后再查询各索引的列
+	for i := range indexes {
+		query := fmt.Sprintf("PRAGMA index_info(%s)", d.QuoteIdent(indexes[i].Name))
+		rows, err := d.db.QueryContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var seqno, cid int
+			var name sql.NullString
+			err := rows.Scan(&seqno, &cid, &name)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+
+			if !name.Valid {
+				name.String = "(expression)"
+			}
+			indexes[i].Columns = append(indexes[i].Columns, name.String)
+		}
+		rows.Close()
+	}
+
+	return indexes, nil
+}
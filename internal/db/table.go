@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"strings"
+)
+
+// TableType 表类型
+type TableType string
+
+// 支持的表类型
+const (
+	TableTypeTable            TableType = "table"
+	TableTypeView             TableType = "view"
+	TableTypeMaterializedView TableType = "materialized view"
+)
+
+// TableInfo 表信息
+type TableInfo struct {
+	Schema  string // 所属模式，仅支持模式的数据库有值
+	Name    string
+	Type    TableType
+	Comment string
+}
+
+// QualifiedName 返回带模式前缀的表名
+func (t TableInfo) QualifiedName() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// IsView 是否为视图或物化视图
+func (t TableInfo) IsView() bool {
+	return t.Type == TableTypeView || t.Type == TableTypeMaterializedView
+}
+
+// GetTable 获取指定表的信息，找不到时按普通表处理
+func (d *Database) GetTable(ctx context.Context, tableName string) (TableInfo, error) {
+	tables, err := d.GetTables(ctx)
+	if err != nil {
+		return TableInfo{}, err
+	}
+
+	return d.FindTable(tables, tableName), nil
+}
+
+// FindTable 在已读取的表列表中查找指定表，找不到时按普通表处理
+func (d *Database) FindTable(tables []TableInfo, tableName string) TableInfo {
+	schema, name := d.SplitTableName(tableName)
+	for _, t := range tables {
+		if t.Name == name && (schema == "" || t.Schema == schema) {
+			return t
+		}
+	}
+	return TableInfo{Schema: schema, Name: name, Type: TableTypeTable}
+}
+
+// SplitTableName 拆分 schema.table 形式的表名
+// 只有支持模式的数据库才拆分，未带前缀时返回默认模式
+func (d *Database) SplitTableName(tableName string) (schema, table string) {
+	if !d.dialect.SupportsSchema() {
+		return "", tableName
+	}
+
+	if schema, table, ok := strings.Cut(tableName, "."); ok {
+		return schema, table
+	}
+	return d.schema, tableName
+}
+
+// QuoteTable 引用表名，带模式前缀时分别引用模式和表名
+func (d *Database) QuoteTable(tableName string) string {
+	return d.quoteTable(d.SplitTableName(tableName))
+}
+
+// quoteTable 引用模式名和表名
+func (d *Database) quoteTable(schema, table string) string {
+	if schema == "" {
+		return d.QuoteIdent(table)
+	}
+	return d.QuoteIdent(schema) + "." + d.QuoteIdent(table)
+}
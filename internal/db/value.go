@@ -0,0 +1,242 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind 跨方言通用的值类别
+type Kind int
+
+// 值类别
+const (
+	KindString Kind = iota
+	KindInt
+	KindFloat
+	KindDecimal
+	KindBool
+	KindTime
+	KindBytes
+	KindUUID // 以字符串形式表示，SQL Server读出的16字节值转换为GUID字符串
+)
+
+// timeLayouts 字符串形式的时间值可能使用的格式
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"15:04:05",
+}
+
+// typeKinds 类型名到值类别的映射，类型名为 TypeName 规范化后的形式
+var typeKinds = map[string]Kind{
+	"tinyint": KindInt, "smallint": KindInt, "mediumint": KindInt, "int": KindInt, "integer": KindInt, "bigint": KindInt,
+	"big int": KindInt, "int2": KindInt, "int4": KindInt, "int8": KindInt, "year": KindInt,
+	"smallserial": KindInt, "serial": KindInt, "bigserial": KindInt, "serial2": KindInt, "serial4": KindInt, "serial8": KindInt,
+
+	"float": KindFloat, "float4": KindFloat, "float8": KindFloat, "real": KindFloat, "double": KindFloat, "double precision": KindFloat,
+
+	"decimal": KindDecimal, "dec": KindDecimal, "numeric": KindDecimal, "fixed": KindDecimal, "money": KindDecimal, "smallmoney": KindDecimal,
+
+	"bool": KindBool, "boolean": KindBool, "bit": KindBool,
+
+	"date": KindTime, "time": KindTime, "timetz": KindTime, "datetime": KindTime, "datetime2": KindTime, "smalldatetime": KindTime,
+	"datetimeoffset": KindTime, "timestamp": KindTime, "timestamptz": KindTime,
+
+	"char": KindString, "character": KindString, "varchar": KindString, "character varying": KindString, "nchar": KindString,
+	"nvarchar": KindString, "bpchar": KindString, "tinytext": KindString, "text": KindString, "mediumtext": KindString,
+	"longtext": KindString, "ntext": KindString, "citext": KindString, "name": KindString, "clob": KindString, "xml": KindString,
+	"uuid": KindUUID, "uniqueidentifier": KindUUID, "interval": KindString, "json": KindString, "jsonb": KindString,
+	"enum": KindString, "set": KindString, "inet": KindString, "cidr": KindString, "macaddr": KindString,
+	"bit varying": KindString, "varbit": KindString,
+
+	"binary": KindBytes, "varbinary": KindBytes, "tinyblob": KindBytes, "blob": KindBytes, "mediumblob": KindBytes,
+	"longblob": KindBytes, "bytea": KindBytes, "image": KindBytes, "rowversion": KindBytes,
+}
+
+// typeModifiers 不影响值类别的类型修饰词
+var typeModifiers = map[string]bool{
+	"unsigned": true,
+	"signed":   true,
+	"zerofill": true,
+}
+
+// typeSuffixes 不影响值类别的类型名后缀，如 timestamp with time zone 按 timestamp 处理
+var typeSuffixes = []string{" with time zone", " without time zone"}
+
+// TypeName 规范化SQL类型名：转为小写，去掉长度、精度等参数和 unsigned 等修饰词，合并空白
+// 如 INT(11) UNSIGNED 为 int，timestamp(6) with time zone 为 timestamp with time zone
+func TypeName(sqlType string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(sqlType) {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth > 0 {
+				depth--
+			}
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+
+	var words []string
+	for _, word := range strings.Fields(b.String()) {
+		if !typeModifiers[word] {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// TypeKind 按完整的类型名确定值类别，不认识的类型返回false
+// 带时区后缀的类型去掉后缀后匹配，如 timestamp with time zone 按 timestamp 处理
+func TypeKind(sqlType string) (Kind, bool) {
+	name := TypeName(sqlType)
+	if strings.HasSuffix(name, "[]") {
+		return KindString, false
+	}
+	// 带长度的BIT是位串而非布尔值，如MySQL的 bit(8) 和PostgreSQL的 bit(3)
+	if name == "bit" && strings.Contains(sqlType, "(") {
+		return KindString, false
+	}
+
+	if kind, ok := typeKinds[name]; ok {
+		return kind, true
+	}
+	for _, suffix := range typeSuffixes {
+		if base, ok := strings.CutSuffix(name, suffix); ok {
+			if kind, ok := typeKinds[base]; ok {
+				return kind, true
+			}
+		}
+	}
+	return KindString, false
+}
+
+// ColumnKind 根据列信息确定值类别
+// 数组和枚举按字符串处理，域类型按其基础类型确定，SQLite声明的类型不认识时按类型亲和性确定
+func ColumnKind(col ColumnInfo) Kind {
+	sqlType := col.Type
+	if col.BaseType != "" {
+		sqlType = col.BaseType
+	}
+	if col.IsArray || strings.HasSuffix(sqlType, "[]") || len(col.EnumValues) > 0 {
+		return KindString
+	}
+
+	if kind, ok := TypeKind(sqlType); ok {
+		return kind
+	}
+	switch col.Affinity {
+	case "INTEGER":
+		return KindInt
+	case "REAL":
+		return KindFloat
+	case "NUMERIC":
+		return KindDecimal
+	case "BLOB":
+		return KindBytes
+	}
+	return KindString
+}
+
+// NormalizeValue 将驱动读出的值转换为值类别对应的Go值
+func NormalizeValue(v interface{}, kind Kind) (interface{}, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		if kind == KindBytes {
+			return val, nil
+		}
+		if kind == KindUUID && len(val) == 16 {
+			return formatGUID(val), nil
+		}
+		return parseString(string(val), kind)
+	case string:
+		if kind == KindBytes {
+			return []byte(val), nil
+		}
+		return parseString(val, kind)
+	case int64:
+		switch kind {
+		case KindBool:
+			return val != 0, nil
+		case KindString, KindDecimal:
+			return strconv.FormatInt(val, 10), nil
+		case KindFloat:
+			return float64(val), nil
+		}
+		return val, nil
+	case float64:
+		switch kind {
+		case KindInt:
+			return int64(val), nil
+		case KindString, KindDecimal:
+			return strconv.FormatFloat(val, 'f', -1, 64), nil
+		}
+		return val, nil
+	case bool:
+		if kind == KindInt {
+			if val {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+		return val, nil
+	case time.Time:
+		if kind == KindString {
+			return val.Format("2006-01-02 15:04:05.999999999"), nil
+		}
+		return val, nil
+	default:
+		return val, nil
+	}
+}
+
+// parseString 将字符串形式的值解析为对应类别的值
+func parseString(s string, kind Kind) (interface{}, error) {
+	switch kind {
+	case KindInt:
+		return strconv.ParseInt(s, 10, 64)
+	case KindFloat:
+		return strconv.ParseFloat(s, 64)
+	case KindBool:
+		switch strings.ToLower(s) {
+		case "1", "t", "true", "y", "yes":
+			return true, nil
+		case "0", "f", "false", "n", "no":
+			return false, nil
+		}
+		return nil, fmt.Errorf("无法解析布尔值: %s", s)
+	case KindTime:
+		// MySQL的零值日期无法解析为时间，转换为零值时间
+		if strings.HasPrefix(s, "0000-00-00") {
+			return time.Time{}, nil
+		}
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("无法解析时间值: %s", s)
+	default:
+		return s, nil
+	}
+}
+
+// formatGUID 将SQL Server的uniqueidentifier格式化为GUID字符串
+// SQL Server按小端序存储前三组，读出的字节需先按组倒序
+func formatGUID(b []byte) string {
+	return fmt.Sprintf("%02x%02x%02x%02x-%02x%02x-%02x%02x-%x-%x",
+		b[3], b[2], b[1], b[0], b[5], b[4], b[7], b[6], b[8:10], b[10:16])
+}
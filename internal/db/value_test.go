@@ -0,0 +1,156 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"INT(11) UNSIGNED ZEROFILL", "int"},
+		{"varchar(255)", "varchar"},
+		{"character varying(20)", "character varying"},
+		{"timestamp(6) with time zone", "timestamp with time zone"},
+		{"numeric(10, 2)", "numeric"},
+		{"  Double   Precision ", "double precision"},
+		{"integer[]", "integer[]"},
+		{"enum('a','b')", "enum"},
+	}
+	for _, tt := range tests {
+		if got := TypeName(tt.in); got != tt.want {
+			t.Errorf("TypeName(%q) = %q，期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTypeKind(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Kind
+		wantOK bool
+	}{
+		{"int(10) unsigned", KindInt, true},
+		{"double precision", KindFloat, true},
+		{"timestamp(6) with time zone", KindTime, true},
+		{"time without time zone", KindTime, true},
+		{"character varying(20)", KindString, true},
+		{"bit", KindBool, true},
+		{"bit(8)", KindString, false},
+		{"bit varying(8)", KindString, true},
+		{"double trouble", KindString, false},
+		{"int list", KindString, false},
+		{"integer[]", KindString, false},
+		{"tsvector", KindString, false},
+	}
+	for _, tt := range tests {
+		if got, ok := TypeKind(tt.in); got != tt.want || ok != tt.wantOK {
+			t.Errorf("TypeKind(%q) = %v, %v，期望 %v, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestColumnKind(t *testing.T) {
+	tests := []struct {
+		name string
+		col  ColumnInfo
+		want Kind
+	}{
+		{"整数", ColumnInfo{Type: "bigint"}, KindInt},
+		{"MySQL无符号整数", ColumnInfo{Type: "int(10) unsigned"}, KindInt},
+		{"PostgreSQL整数别名", ColumnInfo{Type: "int4"}, KindInt},
+		{"双精度", ColumnInfo{Type: "double precision"}, KindFloat},
+		{"定点数", ColumnInfo{Type: "numeric(10,2)"}, KindDecimal},
+		{"SQL Server货币", ColumnInfo{Type: "money"}, KindDecimal},
+		{"布尔", ColumnInfo{Type: "boolean"}, KindBool},
+		{"SQL Server位", ColumnInfo{Type: "bit"}, KindBool},
+		{"MySQL位字段", ColumnInfo{Type: "bit(8)"}, KindString},
+		{"PostgreSQL变长位串", ColumnInfo{Type: "bit varying"}, KindString},
+		{"带时区的时间戳", ColumnInfo{Type: "timestamp with time zone"}, KindTime},
+		{"SQL Server日期时间", ColumnInfo{Type: "datetime2"}, KindTime},
+		{"字符串", ColumnInfo{Type: "character varying(20)"}, KindString},
+		{"二进制", ColumnInfo{Type: "varbinary(16)"}, KindBytes},
+		{"uuid", ColumnInfo{Type: "uuid"}, KindUUID},
+		{"uniqueidentifier", ColumnInfo{Type: "uniqueidentifier"}, KindUUID},
+		{"整数数组", ColumnInfo{Type: "integer[]", IsArray: true, ElemType: "integer"}, KindString},
+		{"时间戳数组", ColumnInfo{Type: "timestamp[]"}, KindString},
+		{"名称含int的枚举", ColumnInfo{Type: "print_status", EnumValues: []string{"queued", "done"}}, KindString},
+		{"名称含date的枚举", ColumnInfo{Type: "update_mode", EnumValues: []string{"auto"}}, KindString},
+		{"域按基础类型", ColumnInfo{Type: "positive_amount", BaseType: "numeric(12,2)"}, KindDecimal},
+		{"数组域", ColumnInfo{Type: "int_list", BaseType: "integer[]"}, KindString},
+		{"几何类型", ColumnInfo{Type: "point"}, KindString},
+		{"区间", ColumnInfo{Type: "interval"}, KindString},
+		{"未知类型", ColumnInfo{Type: "tsvector"}, KindString},
+		{"SQLite按亲和性", ColumnInfo{Type: "UNSIGNED BIG INT", Affinity: "INTEGER"}, KindInt},
+		{"SQLite未知的数值类型", ColumnInfo{Type: "NUMBER", Affinity: "NUMERIC"}, KindDecimal},
+		{"SQLite声明的日期时间", ColumnInfo{Type: "DATETIME", Affinity: "NUMERIC"}, KindTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ColumnKind(tt.col); got != tt.want {
+				t.Errorf("ColumnKind(%+v) = %v，期望 %v", tt.col, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      interface{}
+		kind    Kind
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "NULL", in: nil, kind: KindInt, want: nil},
+		{name: "文本整数", in: []byte("42"), kind: KindInt, want: int64(42)},
+		{name: "无效整数", in: "4x", kind: KindInt, wantErr: true},
+		{name: "文本浮点数", in: "1.5", kind: KindFloat, want: 1.5},
+		{name: "整数转浮点数", in: int64(3), kind: KindFloat, want: float64(3)},
+		{name: "浮点数转整数", in: 3.0, kind: KindInt, want: int64(3)},
+		{name: "定点数保持文本", in: []byte("12.30"), kind: KindDecimal, want: "12.30"},
+		{name: "整数转定点数", in: int64(7), kind: KindDecimal, want: "7"},
+		{name: "整数转布尔", in: int64(1), kind: KindBool, want: true},
+		{name: "文本布尔", in: "f", kind: KindBool, want: false},
+		{name: "无效布尔", in: "maybe", kind: KindBool, wantErr: true},
+		{name: "布尔转整数", in: true, kind: KindInt, want: int64(1)},
+		{name: "日期时间", in: []byte("2024-03-01 08:30:00"), kind: KindTime, want: time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{name: "带时区", in: "2024-03-01T08:30:00+08:00", kind: KindTime, want: time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)},
+		{name: "日期", in: "2024-03-01", kind: KindTime, want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "MySQL零值日期", in: []byte("0000-00-00"), kind: KindTime, want: time.Time{}},
+		{name: "MySQL零值日期时间", in: "0000-00-00 00:00:00", kind: KindTime, want: time.Time{}},
+		{name: "无效时间", in: "yesterday", kind: KindTime, wantErr: true},
+		{name: "时间转文本", in: time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), kind: KindString, want: "2024-03-01 08:30:00"},
+		{name: "文本转二进制", in: "ab", kind: KindBytes, want: []byte("ab")},
+		{name: "二进制保持不变", in: []byte{0, 1}, kind: KindBytes, want: []byte{0, 1}},
+		{name: "文本uuid", in: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", kind: KindUUID, want: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeValue(tt.in, tt.kind)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NormalizeValue(%v) = %v，期望返回错误", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, ok := tt.want.(time.Time); ok {
+				if got, ok := got.(time.Time); !ok || !got.Equal(want) {
+					t.Errorf("NormalizeValue(%v) = %v，期望 %v", tt.in, got, want)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeValue(%v) = %#v，期望 %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
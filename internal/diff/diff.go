@@ -0,0 +1,196 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Context 统一格式差异中每处修改前后保留的上下文行数
+const Context = 3
+
+// opKind 编辑操作类型
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op 一行的编辑操作，a、b为该行在旧文本和新文本中的下标
+type op struct {
+	kind opKind
+	a, b int
+}
+
+// Unified 返回旧文本到新文本的统一格式差异，两者相同时返回空字符串
+// oldName为空时表示新建文件
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	a, b := splitLines(oldText), splitLines(newText)
+	ops := edits(a, b)
+
+	var buf strings.Builder
+	if oldName == "" {
+		oldName = "/dev/null"
+	}
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldName, newName)
+
+	for _, h := range hunks(ops) {
+		var body strings.Builder
+		aStart, bStart, aCount, bCount := -1, -1, 0, 0
+		for _, o := range h {
+			if aStart < 0 {
+				aStart, bStart = o.a, o.b
+			}
+			switch o.kind {
+			case opEqual:
+				writeLine(&body, " ", a[o.a])
+				aCount++
+				bCount++
+			case opDelete:
+				writeLine(&body, "-", a[o.a])
+				aCount++
+			case opInsert:
+				writeLine(&body, "+", b[o.b])
+				bCount++
+			}
+		}
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		buf.WriteString(body.String())
+	}
+	return buf.String()
+}
+
+// splitLines 按行拆分文本，每行保留末尾的换行，以区分最后一行是否以换行结尾
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// writeLine 写出差异中带前缀的一行，没有换行结尾的行按 diff 的习惯加上说明
+func writeLine(buf *strings.Builder, prefix, line string) {
+	buf.WriteString(prefix + line)
+	if !strings.HasSuffix(line, "\n") {
+		buf.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// hunkRange 返回差异块头中的行范围，行数为0时起始行为前一行
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// hunks 将编辑操作分组为差异块，相距不超过两倍上下文行数的修改合并为一块
+func hunks(ops []op) [][]op {
+	var result [][]op
+	start, last := -1, -1 // 当前块的起始位置和最后一处修改的位置
+
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		if start >= 0 && i-last > 2*Context {
+			result = append(result, ops[start:last+Context+1])
+			start = -1
+		}
+		if start < 0 {
+			start = i - Context
+			if start < 0 {
+				start = 0
+			}
+		}
+		last = i
+	}
+
+	if start >= 0 {
+		end := last + Context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		result = append(result, ops[start:end])
+	}
+	return result
+}
+
+// edits 用 Myers 算法计算旧文本行a到新文本行b的最短编辑序列
+func edits(a, b []string) []op {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+search:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int{}, v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// 从终点沿记录的路径回溯
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, op{opEqual, x, y})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, op{opInsert, x, y})
+			} else {
+				x--
+				ops = append(ops, op{opDelete, x, y})
+			}
+		}
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
@@ -0,0 +1,155 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numbered 返回 line1 到 lineN 的文本，每行以换行结尾
+func numbered(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line%d\n", i)
+	}
+	return b.String()
+}
+
+// replace 将文本中的第i行(从1开始)替换为s
+func replace(text string, i int, s string) string {
+	lines := strings.SplitAfter(text, "\n")
+	lines[i-1] = s + "\n"
+	return strings.Join(lines, "")
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldName string
+		old     string
+		new     string
+		want    string
+	}{
+		{
+			name: "相同",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name:    "新建文件",
+			oldName: "",
+			old:     "",
+			new:     "a\nb\n",
+			want:    "--- /dev/null\n+++ new.go\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:    "删除全部内容",
+			oldName: "old.go",
+			old:     "a\n",
+			new:     "",
+			want:    "--- old.go\n+++ new.go\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			name:    "开头的修改",
+			oldName: "old.go",
+			old:     numbered(6),
+			new:     replace(numbered(6), 1, "first"),
+			want:    "--- old.go\n+++ new.go\n@@ -1,4 +1,4 @@\n-line1\n+first\n line2\n line3\n line4\n",
+		},
+		{
+			name:    "末尾的修改",
+			oldName: "old.go",
+			old:     numbered(6),
+			new:     numbered(6) + "line7\n",
+			want:    "--- old.go\n+++ new.go\n@@ -4,3 +4,4 @@\n line4\n line5\n line6\n+line7\n",
+		},
+		{
+			name:    "新文本末尾没有换行",
+			oldName: "old.go",
+			old:     "a\nb\n",
+			new:     "a\nb",
+			want:    "--- old.go\n+++ new.go\n@@ -1,2 +1,2 @@\n a\n-b\n+b\n\\ No newline at end of file\n",
+		},
+		{
+			name:    "旧文本末尾没有换行",
+			oldName: "old.go",
+			old:     "a\nb",
+			new:     "a\nb\nc\n",
+			want:    "--- old.go\n+++ new.go\n@@ -1,2 +1,3 @@\n a\n-b\n\\ No newline at end of file\n+b\n+c\n",
+		},
+		{
+			name:    "两侧末尾都没有换行",
+			oldName: "old.go",
+			old:     "a\nb",
+			new:     "a\nc",
+			want:    "--- old.go\n+++ new.go\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+		},
+		{
+			name:    "相近的修改合并为一块",
+			oldName: "old.go",
+			old:     numbered(12),
+			new:     replace(replace(numbered(12), 3, "three"), 9, "nine"),
+			want: "--- old.go\n+++ new.go\n@@ -1,12 +1,12 @@\n line1\n line2\n-line3\n+three\n" +
+				" line4\n line5\n line6\n line7\n line8\n-line9\n+nine\n line10\n line11\n line12\n",
+		},
+		{
+			name:    "相距较远的修改分为两块",
+			oldName: "old.go",
+			old:     numbered(20),
+			new:     replace(replace(numbered(20), 2, "two"), 18, "eighteen"),
+			want: "--- old.go\n+++ new.go\n" +
+				"@@ -1,5 +1,5 @@\n line1\n-line2\n+two\n line3\n line4\n line5\n" +
+				"@@ -15,6 +15,6 @@\n line15\n line16\n line17\n-line18\n+eighteen\n line19\n line20\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified(tt.oldName, "new.go", tt.old, tt.new); got != tt.want {
+				t.Errorf("Unified() =\n%s\n期望\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEdits(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int // 最短编辑序列中的修改行数
+	}{
+		{name: "都为空", a: "", b: "", want: 0},
+		{name: "旧文本为空", a: "", b: "x\ny\n", want: 2},
+		{name: "新文本为空", a: "x\ny\n", b: "", want: 2},
+		{name: "交错修改", a: "a\nb\nc\na\nb\nb\na\n", b: "c\nb\na\nb\na\nc\n", want: 5},
+		{name: "只有末尾的换行不同", a: "x\ny", b: "x\ny\n", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := splitLines(tt.a), splitLines(tt.b)
+			var gotA, gotB []string
+			changes := 0
+			for _, o := range edits(a, b) {
+				switch o.kind {
+				case opEqual:
+					gotA, gotB = append(gotA, a[o.a]), append(gotB, b[o.b])
+				case opDelete:
+					gotA = append(gotA, a[o.a])
+					changes++
+				case opInsert:
+					gotB = append(gotB, b[o.b])
+					changes++
+				}
+			}
+			if changes != tt.want {
+				t.Errorf("edits() 修改了 %d 行，期望 %d", changes, tt.want)
+			}
+			// 按编辑操作应能还原两侧的全部行
+			if strings.Join(gotA, "") != tt.a || strings.Join(gotB, "") != tt.b {
+				t.Errorf("edits() 无法还原文本: 旧 %q 新 %q", gotA, gotB)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package docgen
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// Format 文档格式
+type Format string
+
+// 支持的文档格式
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// Options 数据字典生成选项
+type Options struct {
+	Format  Format
+	Title   string
+	Include []string // 需要包含的表，支持通配符，为空时包含全部表
+	Exclude []string // 需要排除的表，支持通配符
+	Workers int      // 并发读取表结构的协程数，0表示使用 db.DefaultWorkers
+}
+
+// TableDoc 单个表的文档数据
+type TableDoc struct {
+	db.TableInfo
+	Columns     []ColumnDoc
+	Indexes     []db.IndexInfo
+	ForeignKeys []db.ForeignKey
+}
+
+// ColumnDoc 单个列的文档数据
+type ColumnDoc struct {
+	db.ColumnInfo
+	Key string // PK、FK 或为空
+}
+
+// Generate 读取数据库结构并输出数据字典
+func Generate(ctx context.Context, database *db.Database, w io.Writer, opts Options) error {
+	tables, err := Load(ctx, database, opts)
+	if err != nil {
+		return err
+	}
+
+	return Render(w, tables, opts)
+}
+
+// Load 读取筛选后各表和视图的注释、列、索引和外键
+func Load(ctx context.Context, database *db.Database, opts Options) ([]TableDoc, error) {
+	all, err := database.GetTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取表列表失败: %v", err)
+	}
+
+	byName := map[string]db.TableInfo{}
+	names := make([]string, len(all))
+	for i, t := range all {
+		byName[t.QualifiedName()] = t
+		names[i] = t.QualifiedName()
+	}
+
+	names, err = db.FilterTables(names, opts.Include, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]TableDoc, len(names))
+	err = db.EachTable(ctx, names, opts.Workers, func(ctx context.Context, i int, name string) error {
+		columns, err := database.GetTableInfo(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取表 %s 结构失败: %v", name, err)
+		}
+
+		indexes, err := database.GetIndexes(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取表 %s 索引失败: %v", name, err)
+		}
+
+		keys, err := database.GetForeignKeys(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取表 %s 外键失败: %v", name, err)
+		}
+
+		foreign := map[string]bool{}
+		for _, fk := range keys {
+			for _, col := range fk.Columns {
+				foreign[col] = true
+			}
+		}
+
+		doc := TableDoc{
+			TableInfo:   byName[name],
+			Indexes:     indexes,
+			ForeignKeys: keys,
+		}
+		for _, col := range columns {
+			cd := ColumnDoc{ColumnInfo: col}
+			switch {
+			case col.IsPrimary:
+				cd.Key = "PK"
+			case foreign[col.Name]:
+				cd.Key = "FK"
+			}
+			doc.Columns = append(doc.Columns, cd)
+		}
+		tables[i] = doc
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// Render 按格式输出数据字典
+func Render(w io.Writer, tables []TableDoc, opts Options) error {
+	if opts.Title == "" {
+		opts.Title = "数据字典"
+	}
+
+	switch opts.Format {
+	case FormatMarkdown, "":
+		return renderMarkdown(w, tables, opts.Title)
+	case FormatHTML:
+		return renderHTML(w, tables, opts.Title)
+	default:
+		return fmt.Errorf("不支持的文档格式: %s", opts.Format)
+	}
+}
+
+// DefaultText 返回默认值的展示文本
+func (c ColumnDoc) DefaultText() string {
+	if c.Default == nil {
+		return ""
+	}
+	return *c.Default
+}
+
+// NullableText 返回可空性的展示文本
+func (c ColumnDoc) NullableText() string {
+	if c.IsNullable {
+		return "是"
+	}
+	return "否"
+}
+
+// TypeText 返回表类型的展示文本
+func (t TableDoc) TypeText() string {
+	switch t.Type {
+	case db.TableTypeView:
+		return "视图"
+	case db.TableTypeMaterializedView:
+		return "物化视图"
+	default:
+		return "表"
+	}
+}
@@ -0,0 +1,155 @@
+package docgen
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// markdownEscaper 转义注释等文本中的HTML字符，避免被Markdown渲染器当作标签
+var markdownEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// markdownText 转义Markdown正文中的HTML字符
+func markdownText(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// markdownCell 转义Markdown表格单元格中的特殊字符
+func markdownCell(s string) string {
+	s = markdownText(s)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// markdownAnchor 按GitHub的规则生成标题锚点
+func markdownAnchor(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// renderMarkdown 输出Markdown数据字典
+func renderMarkdown(w io.Writer, tables []TableDoc, title string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<!-- 代码由 trade2sql 自动生成 -->\n# %s\n\n", markdownText(title))
+
+	bw.WriteString("| 表名 | 类型 | 注释 |\n| --- | --- | --- |\n")
+	for _, t := range tables {
+		fmt.Fprintf(bw, "| [%s](#%s) | %s | %s |\n", markdownCell(t.QualifiedName()), markdownAnchor(t.QualifiedName()), t.TypeText(), markdownCell(t.Comment))
+	}
+
+	for _, t := range tables {
+		fmt.Fprintf(bw, "\n## %s\n\n", t.QualifiedName())
+		if t.IsView() {
+			fmt.Fprintf(bw, "*%s*\n\n", t.TypeText())
+		}
+		if t.Comment != "" {
+			fmt.Fprintf(bw, "%s\n\n", markdownText(t.Comment))
+		}
+
+		bw.WriteString("| 列名 | 类型 | 可空 | 默认值 | 键 | 注释 |\n| --- | --- | --- | --- | --- | --- |\n")
+		for _, c := range t.Columns {
+			fmt.Fprintf(bw, "| %s | %s | %s | %s | %s | %s |\n",
+				markdownCell(c.Name), markdownCell(c.Type), c.NullableText(),
+				markdownCell(c.DefaultText()), c.Key, markdownCell(c.Comment))
+		}
+
+		if len(t.Indexes) > 0 {
+			bw.WriteString("\n**索引**\n\n| 索引名 | 列 | 唯一 |\n| --- | --- | --- |\n")
+			for _, idx := range t.Indexes {
+				unique := "否"
+				if idx.IsUnique {
+					unique = "是"
+				}
+				fmt.Fprintf(bw, "| %s | %s | %s |\n", markdownCell(idx.Name), markdownCell(strings.Join(idx.Columns, ", ")), unique)
+			}
+		}
+
+		if len(t.ForeignKeys) > 0 {
+			bw.WriteString("\n**外键**\n\n| 列 | 引用 |\n| --- | --- |\n")
+			for _, fk := range t.ForeignKeys {
+				ref := fmt.Sprintf("[%s](#%s)", markdownCell(fk.RefTable), markdownAnchor(fk.RefTable))
+				if cols := strings.Join(fk.RefColumns, ", "); cols != "" {
+					ref += fmt.Sprintf(" (%s)", markdownCell(cols))
+				}
+				fmt.Fprintf(bw, "| %s | %s |\n", markdownCell(strings.Join(fk.Columns, ", ")), ref)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// htmlTemplate 独立HTML数据字典模板
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; margin: 2em auto; max-width: 1100px; color: #24292f; }
+table { border-collapse: collapse; width: 100%; margin: 0.5em 0 1.5em; }
+th, td { border: 1px solid #d0d7de; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { font-family: SFMono-Regular, Consolas, monospace; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.3em; margin-top: 2em; }
+.key { font-weight: bold; color: #0969da; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<tr><th>表名</th><th>类型</th><th>注释</th></tr>
+{{range .Tables}}<tr><td><a href="#{{.QualifiedName}}">{{.QualifiedName}}</a></td><td>{{.TypeText}}</td><td>{{.Comment}}</td></tr>
+{{end}}</table>
+{{range .Tables}}
+<h2 id="{{.QualifiedName}}">{{.QualifiedName}}</h2>
+{{if .IsView}}<p><em>{{.TypeText}}</em></p>{{end}}
+{{if .Comment}}<p>{{.Comment}}</p>{{end}}
+<table>
+<tr><th>列名</th><th>类型</th><th>可空</th><th>默认值</th><th>键</th><th>注释</th></tr>
+{{range .Columns}}<tr><td><code>{{.Name}}</code></td><td><code>{{.Type}}</code></td><td>{{.NullableText}}</td><td>{{if .Default}}<code>{{.DefaultText}}</code>{{end}}</td><td class="key">{{.Key}}</td><td>{{.Comment}}</td></tr>
+{{end}}</table>
+{{if .Indexes}}<h3>索引</h3>
+<table>
+<tr><th>索引名</th><th>列</th><th>唯一</th></tr>
+{{range .Indexes}}<tr><td><code>{{.Name}}</code></td><td>{{join .Columns}}</td><td>{{if .IsUnique}}是{{else}}否{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{if .ForeignKeys}}<h3>外键</h3>
+<table>
+<tr><th>列</th><th>引用</th></tr>
+{{range .ForeignKeys}}<tr><td>{{join .Columns}}</td><td><a href="#{{.RefTable}}">{{.RefTable}}</a>{{with join .RefColumns}} ({{.}}){{end}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+</body>
+</html>
+`
+
+// renderHTML 输出独立的HTML数据字典
+func renderHTML(w io.Writer, tables []TableDoc, title string) error {
+	tmpl, err := template.New("doc").Funcs(template.FuncMap{
+		"join": func(items []string) string { return strings.Join(items, ", ") },
+	}).Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, struct {
+		Title  string
+		Tables []TableDoc
+	}{
+		Title:  title,
+		Tables: tables,
+	})
+}
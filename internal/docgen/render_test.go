@@ -0,0 +1,89 @@
+package docgen
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/trade2sql/internal/db"
+)
+
+var update = flag.Bool("update", false, "更新 testdata 中的期望输出")
+
+// testTables 包含需要转义的注释和默认值，以及引用其他表的外键
+func testTables() []TableDoc {
+	defaultName := "'<none>'"
+	defaultPipe := "'a|b'"
+	return []TableDoc{
+		{
+			TableInfo: db.TableInfo{Schema: "sales", Name: "users", Comment: "用户 <b>表</b> & 账号"},
+			Columns: []ColumnDoc{
+				{ColumnInfo: db.ColumnInfo{Name: "id", Type: "bigint", IsPrimary: true}, Key: "PK"},
+				{ColumnInfo: db.ColumnInfo{Name: "name", Type: "varchar(64)", IsNullable: true, Default: &defaultName, Comment: "名称 <script>alert(1)</script>"}},
+				{ColumnInfo: db.ColumnInfo{Name: "tags", Type: "text", Default: &defaultPipe, Comment: "多行\n注释 | 含竖线"}},
+			},
+			Indexes: []db.IndexInfo{
+				{Name: "idx_users_name", Columns: []string{"name", "tags"}, IsUnique: true},
+			},
+		},
+		{
+			TableInfo: db.TableInfo{Schema: "sales", Name: "orders"},
+			Columns: []ColumnDoc{
+				{ColumnInfo: db.ColumnInfo{Name: "id", Type: "bigint", IsPrimary: true}, Key: "PK"},
+				{ColumnInfo: db.ColumnInfo{Name: "user_id", Type: "bigint"}, Key: "FK"},
+			},
+			ForeignKeys: []db.ForeignKey{
+				{Name: "fk_orders_user", Columns: []string{"user_id"}, RefTable: "sales.users", RefColumns: []string{"id"}},
+			},
+		},
+		{
+			TableInfo: db.TableInfo{Schema: "sales", Name: "active_users", Type: db.TableTypeView, Comment: "活跃用户"},
+			Columns: []ColumnDoc{
+				{ColumnInfo: db.ColumnInfo{Name: "id", Type: "bigint"}},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		golden string
+	}{
+		{name: "Markdown", format: FormatMarkdown, golden: "dictionary.md"},
+		{name: "HTML", format: FormatHTML, golden: "dictionary.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, testTables(), Options{Format: tt.format, Title: "销售库 <数据字典>"}); err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != string(want) {
+				t.Errorf("Render(%s) 输出与 %s 不一致，可用 -update 更新:\n%s", tt.format, path, got)
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, testTables(), Options{Format: "pdf"}); err == nil {
+		t.Error("Render(pdf) 没有返回错误")
+	}
+}
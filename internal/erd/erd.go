@@ -0,0 +1,185 @@
+package erd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// Format 图表格式
+type Format string
+
+// 支持的图表格式
+const (
+	FormatMermaid  Format = "mermaid"
+	FormatPlantUML Format = "plantuml"
+	FormatDOT      Format = "dot"
+)
+
+// Options ER图生成选项
+type Options struct {
+	Format     Format
+	Include    []string // 需要包含的表，支持通配符，为空时包含全部表
+	Exclude    []string // 需要排除的表，支持通配符
+	AllColumns bool     // 输出全部列，默认只输出主键和外键列
+	Workers    int      // 并发读取表结构的协程数，0表示使用 db.DefaultWorkers
+}
+
+// Table 图中的表
+type Table struct {
+	Name        string
+	Columns     []db.ColumnInfo
+	ForeignKeys []db.ForeignKey
+}
+
+// Generate 读取数据库结构并输出ER图
+func Generate(ctx context.Context, database *db.Database, w io.Writer, opts Options) error {
+	tables, err := Load(ctx, database, opts)
+	if err != nil {
+		return err
+	}
+
+	return Render(w, tables, opts)
+}
+
+// Load 读取筛选后各表的列和外键
+func Load(ctx context.Context, database *db.Database, opts Options) ([]Table, error) {
+	names, err := database.GetTableList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取表列表失败: %v", err)
+	}
+
+	names, err = db.FilterTables(names, opts.Include, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]Table, len(names))
+	err = db.EachTable(ctx, names, opts.Workers, func(ctx context.Context, i int, name string) error {
+		columns, err := database.GetTableInfo(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取表 %s 结构失败: %v", name, err)
+		}
+
+		keys, err := database.GetForeignKeys(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取表 %s 外键失败: %v", name, err)
+		}
+
+		tables[i] = Table{
+			Name:        name,
+			Columns:     columns,
+			ForeignKeys: keys,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// Render 按格式输出ER图，只保留两端都在图中的外键
+func Render(w io.Writer, tables []Table, opts Options) error {
+	d := newDiagram(tables, opts.AllColumns)
+
+	switch opts.Format {
+	case FormatMermaid, "":
+		return renderMermaid(w, d)
+	case FormatPlantUML:
+		return renderPlantUML(w, d)
+	case FormatDOT:
+		return renderDOT(w, d)
+	default:
+		return fmt.Errorf("不支持的图表格式: %s", opts.Format)
+	}
+}
+
+// diagram 渲染前整理好的图数据
+type diagram struct {
+	tables []diagramTable
+	edges  []edge
+}
+
+// diagramTable 图中的表及要显示的列
+type diagramTable struct {
+	name    string
+	columns []diagramColumn
+}
+
+// diagramColumn 图中的列
+type diagramColumn struct {
+	name      string
+	dataType  string
+	isPrimary bool
+	isForeign bool
+	nullable  bool
+}
+
+// edge 外键关系，from引用to
+type edge struct {
+	from     string
+	to       string
+	label    string
+	nullable bool
+}
+
+// newDiagram 整理表、列和外键关系
+func newDiagram(tables []Table, allColumns bool) *diagram {
+	d := &diagram{}
+
+	included := map[string]bool{}
+	for _, t := range tables {
+		included[t.Name] = true
+	}
+
+	for _, t := range tables {
+		foreign := map[string]bool{}
+		for _, fk := range t.ForeignKeys {
+			for _, col := range fk.Columns {
+				foreign[col] = true
+			}
+		}
+
+		nullable := map[string]bool{}
+		dt := diagramTable{name: t.Name}
+		for _, col := range t.Columns {
+			nullable[col.Name] = col.IsNullable
+			if !allColumns && !col.IsPrimary && !foreign[col.Name] {
+				continue
+			}
+			dt.columns = append(dt.columns, diagramColumn{
+				name:      col.Name,
+				dataType:  col.Type,
+				isPrimary: col.IsPrimary,
+				isForeign: foreign[col.Name],
+				nullable:  col.IsNullable,
+			})
+		}
+		d.tables = append(d.tables, dt)
+
+		for _, fk := range t.ForeignKeys {
+			if !included[fk.RefTable] {
+				continue
+			}
+
+			e := edge{
+				from:  t.Name,
+				to:    fk.RefTable,
+				label: strings.Join(fk.Columns, ","),
+			}
+			for _, col := range fk.Columns {
+				if nullable[col] {
+					e.nullable = true
+				}
+			}
+			d.edges = append(d.edges, e)
+		}
+	}
+
+	return d
+}
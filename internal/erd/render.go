@@ -0,0 +1,141 @@
+package erd
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mermaidUnsafe Mermaid标识符中不允许出现的字符
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_\-()\[\]]+`)
+
+// mermaidIdent 将名称转换为Mermaid可接受的标识符
+func mermaidIdent(s string) string {
+	return mermaidUnsafe.ReplaceAllString(s, "_")
+}
+
+// renderMermaid 输出Mermaid erDiagram
+func renderMermaid(w io.Writer, d *diagram) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("%% 代码由 trade2sql 自动生成\nerDiagram\n")
+
+	for _, t := range d.tables {
+		fmt.Fprintf(bw, "    %s {\n", mermaidIdent(t.name))
+		for _, c := range t.columns {
+			var keys []string
+			if c.isPrimary {
+				keys = append(keys, "PK")
+			}
+			if c.isForeign {
+				keys = append(keys, "FK")
+			}
+			fmt.Fprintf(bw, "        %s %s", mermaidIdent(c.dataType), mermaidIdent(c.name))
+			if len(keys) > 0 {
+				fmt.Fprintf(bw, " %s", strings.Join(keys, ","))
+			}
+			bw.WriteString("\n")
+		}
+		bw.WriteString("    }\n")
+	}
+
+	for _, e := range d.edges {
+		target := "||"
+		if e.nullable {
+			target = "o|"
+		}
+		fmt.Fprintf(bw, "    %s }o--%s %s : %s\n", mermaidIdent(e.from), target, mermaidIdent(e.to), strconv.Quote(e.label))
+	}
+
+	return bw.Flush()
+}
+
+// renderPlantUML 输出PlantUML实体关系图
+func renderPlantUML(w io.Writer, d *diagram) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("@startuml\n' 代码由 trade2sql 自动生成\nhide circle\nskinparam linetype ortho\n\n")
+
+	alias := map[string]string{}
+	for i, t := range d.tables {
+		alias[t.name] = fmt.Sprintf("t%d", i)
+	}
+
+	for _, t := range d.tables {
+		fmt.Fprintf(bw, "entity %s as %s {\n", strconv.Quote(t.name), alias[t.name])
+
+		// 主键列在分隔线之上
+		for _, c := range t.columns {
+			if c.isPrimary {
+				fmt.Fprintf(bw, "  * %s : %s <<PK>>\n", c.name, c.dataType)
+			}
+		}
+		bw.WriteString("  --\n")
+		for _, c := range t.columns {
+			if c.isPrimary {
+				continue
+			}
+			marker := "  "
+			if !c.nullable {
+				marker = "  * "
+			}
+			fmt.Fprintf(bw, "%s%s : %s", marker, c.name, c.dataType)
+			if c.isForeign {
+				bw.WriteString(" <<FK>>")
+			}
+			bw.WriteString("\n")
+		}
+		bw.WriteString("}\n\n")
+	}
+
+	for _, e := range d.edges {
+		target := "||"
+		if e.nullable {
+			target = "o|"
+		}
+		fmt.Fprintf(bw, "%s }o--%s %s : %s\n", alias[e.from], target, alias[e.to], e.label)
+	}
+	bw.WriteString("@enduml\n")
+
+	return bw.Flush()
+}
+
+// renderDOT 输出Graphviz DOT
+func renderDOT(w io.Writer, d *diagram) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("// 代码由 trade2sql 自动生成\ndigraph erd {\n  rankdir=LR;\n  node [shape=plaintext];\n\n")
+
+	for _, t := range d.tables {
+		fmt.Fprintf(bw, "  %s [label=<<TABLE BORDER=\"0\" CELLBORDER=\"1\" CELLSPACING=\"0\">", strconv.Quote(t.name))
+		fmt.Fprintf(bw, "<TR><TD BGCOLOR=\"lightgrey\"><B>%s</B></TD></TR>", html.EscapeString(t.name))
+		for _, c := range t.columns {
+			var keys []string
+			if c.isPrimary {
+				keys = append(keys, "PK")
+			}
+			if c.isForeign {
+				keys = append(keys, "FK")
+			}
+			label := fmt.Sprintf("%s: %s", c.name, c.dataType)
+			if len(keys) > 0 {
+				label += " (" + strings.Join(keys, ",") + ")"
+			}
+			fmt.Fprintf(bw, "<TR><TD ALIGN=\"LEFT\">%s</TD></TR>", html.EscapeString(label))
+		}
+		bw.WriteString("</TABLE>>];\n")
+	}
+
+	bw.WriteString("\n")
+	for _, e := range d.edges {
+		style := "solid"
+		if e.nullable {
+			style = "dashed"
+		}
+		fmt.Fprintf(bw, "  %s -> %s [label=%s, style=%s];\n", strconv.Quote(e.from), strconv.Quote(e.to), strconv.Quote(e.label), style)
+	}
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
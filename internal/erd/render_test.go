@@ -0,0 +1,147 @@
+package erd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// testTables 订单引用用户，审计日志引用不在图中的表
+var testTables = []Table{
+	{
+		Name: "users",
+		Columns: []db.ColumnInfo{
+			{Name: "id", Type: "bigint", IsPrimary: true},
+			{Name: "name", Type: "varchar(64)"},
+		},
+	},
+	{
+		Name: "orders",
+		Columns: []db.ColumnInfo{
+			{Name: "id", Type: "bigint", IsPrimary: true},
+			{Name: "user_id", Type: "bigint", IsNullable: true},
+			{Name: "note", Type: "text", IsNullable: true},
+		},
+		ForeignKeys: []db.ForeignKey{
+			{Name: "fk_orders_user", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}},
+			{Name: "fk_orders_shop", Columns: []string{"id"}, RefTable: "shops", RefColumns: []string{"id"}},
+		},
+	},
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "Mermaid",
+			opts: Options{Format: FormatMermaid},
+			want: `%% 代码由 trade2sql 自动生成
+erDiagram
+    users {
+        bigint id PK
+    }
+    orders {
+        bigint id PK,FK
+        bigint user_id FK
+    }
+    orders }o--o| users : "user_id"
+`,
+		},
+		{
+			name: "Mermaid全部列",
+			opts: Options{AllColumns: true},
+			want: `%% 代码由 trade2sql 自动生成
+erDiagram
+    users {
+        bigint id PK
+        varchar(64) name
+    }
+    orders {
+        bigint id PK,FK
+        bigint user_id FK
+        text note
+    }
+    orders }o--o| users : "user_id"
+`,
+		},
+		{
+			name: "PlantUML",
+			opts: Options{Format: FormatPlantUML},
+			want: `@startuml
+' 代码由 trade2sql 自动生成
+hide circle
+skinparam linetype ortho
+
+entity "users" as t0 {
+  * id : bigint <<PK>>
+  --
+}
+
+entity "orders" as t1 {
+  * id : bigint <<PK>>
+  --
+  user_id : bigint <<FK>>
+}
+
+t1 }o--o| t0 : user_id
+@enduml
+`,
+		},
+		{
+			name: "DOT",
+			opts: Options{Format: FormatDOT},
+			want: `// 代码由 trade2sql 自动生成
+digraph erd {
+  rankdir=LR;
+  node [shape=plaintext];
+
+  "users" [label=<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR><TD BGCOLOR="lightgrey"><B>users</B></TD></TR><TR><TD ALIGN="LEFT">id: bigint (PK)</TD></TR></TABLE>>];
+  "orders" [label=<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR><TD BGCOLOR="lightgrey"><B>orders</B></TD></TR><TR><TD ALIGN="LEFT">id: bigint (PK,FK)</TD></TR><TR><TD ALIGN="LEFT">user_id: bigint (FK)</TD></TR></TABLE>>];
+
+  "orders" -> "users" [label="user_id", style=dashed];
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Render(&buf, testTables, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("输出 =\n%s\n期望\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	err := Render(&bytes.Buffer{}, testTables, Options{Format: "svg"})
+	if err == nil {
+		t.Error("期望不支持的格式返回错误")
+	}
+}
+
+func TestMermaidIdent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"users", "users"},
+		{"sales.orders", "sales_orders"},
+		{"character varying(20)", "character_varying(20)"},
+		{"numeric(10, 2)", "numeric(10_2)"},
+	}
+	for _, tt := range tests {
+		if got := mermaidIdent(tt.in); got != tt.want {
+			t.Errorf("mermaidIdent(%q) = %q，期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+	"github.com/trade2sql/internal/generator"
+)
+
+// Format 导出格式
+type Format string
+
+// 支持的导出格式
+const (
+	FormatInsert Format = "insert"
+	FormatCSV    Format = "csv"
+	FormatJSONL  Format = "jsonl"
+	FormatGo     Format = "go"
+)
+
+// Options 导出选项
+type Options struct {
+	Table     string
+	Where     string
+	Limit     int
+	Format    Format
+	Dialect   string                 // INSERT语句使用的方言，默认与源库相同
+	Generator config.GeneratorConfig // 生成器配置，Go测试数据按其确定包名、字段名和字段类型
+}
+
+// rowWriter 按格式逐行写出数据
+type rowWriter interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// Export 将表数据按指定格式写出
+func Export(ctx context.Context, database *db.Database, w io.Writer, opts Options) error {
+	columns, err := database.GetTableInfo(ctx, opts.Table)
+	if err != nil {
+		return err
+	}
+
+	dialect := database.Dialect()
+	if opts.Dialect != "" {
+		dialect, err = db.LookupDialect(opts.Dialect)
+		if err != nil {
+			return err
+		}
+	}
+
+	schema, table := database.SplitTableName(opts.Table)
+
+	var rw rowWriter
+	switch opts.Format {
+	case FormatInsert:
+		// 只有目标方言与源库相同时保留模式前缀
+		quoted := dialect.QuoteIdent(table)
+		if schema != "" && dialect.Name() == database.Type() {
+			quoted = dialect.QuoteIdent(schema) + "." + quoted
+		}
+		rw = newInsertWriter(w, dialect, quoted, columns)
+	case FormatCSV:
+		rw, err = newCSVWriter(w, columns)
+	case FormatJSONL:
+		rw = newJSONLWriter(w, columns)
+	case FormatGo:
+		// 与生成的结构体保持一致，不导出单表设置中忽略的列
+		// 嵌入的基础结构体的字段不能直接写在结构体字面量中，也不导出
+		columns = generator.FilterColumns(opts.Table, columns, opts.Generator)
+		_, columns = generator.BaseModel(columns, opts.Generator)
+		_, structName := generator.TableNaming(database, opts.Table, opts.Generator)
+		rw = newGoWriter(w, opts.Generator, opts.Table, structName, columns)
+	default:
+		return fmt.Errorf("不支持的导出格式: %s", opts.Format)
+	}
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(columns))
+	kinds := make([]db.Kind, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+		kinds[i] = db.ColumnKind(col)
+	}
+
+	rows, err := database.Select(ctx, db.SelectQuery{
+		Table:   opts.Table,
+		Columns: names,
+		Where:   opts.Where,
+		Limit:   opts.Limit,
+	})
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		err := rows.Scan(ptrs...)
+		if err != nil {
+			return err
+		}
+
+		row := make([]interface{}, len(columns))
+		for i, v := range values {
+			row[i], err = db.NormalizeValue(v, kinds[i])
+			if err != nil {
+				return fmt.Errorf("转换列 %s 的值失败: %v", names[i], err)
+			}
+		}
+
+		err = rw.WriteRow(row)
+		if err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return rw.Close()
+}
@@ -0,0 +1,241 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+	"github.com/trade2sql/internal/generator"
+)
+
+// openFixture 创建包含各类列的SQLite测试表
+func openFixture(t *testing.T) *db.Database {
+	t.Helper()
+	database, err := db.Connect(context.Background(), "sqlite", filepath.Join(t.TempDir(), "fixture.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	statements := []string{
+		`CREATE TABLE products (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			price DECIMAL(10,2) NOT NULL,
+			discount DECIMAL(10,2),
+			uid UUID,
+			active BOOLEAN NOT NULL,
+			created_at DATETIME NOT NULL,
+			data BLOB
+		)`,
+		`INSERT INTO products VALUES (1, 'Tea "Green"', '12.50', NULL, '6ba7b810-9dad-11d1-80b4-00c04fd430c8', 1, '2024-03-01 08:30:00', X'00FF')`,
+		`INSERT INTO products VALUES (2, 'Coffee, dark', 8, 0.5, NULL, 0, '2024-03-02 09:00:00', NULL)`,
+	}
+	for _, stmt := range statements {
+		if _, err := database.DB().Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	return database
+}
+
+func TestExport(t *testing.T) {
+	database := openFixture(t)
+
+	tests := []struct {
+		name    string
+		format  Format
+		dialect string
+		want    string
+	}{
+		{
+			name:   "INSERT语句",
+			format: FormatInsert,
+			want: `INSERT INTO "products" ("id", "name", "price", "discount", "uid", "active", "created_at", "data") VALUES (1, 'Tea "Green"', '12.5', NULL, '6ba7b810-9dad-11d1-80b4-00c04fd430c8', 1, '2024-03-01 08:30:00', X'00ff');
+INSERT INTO "products" ("id", "name", "price", "discount", "uid", "active", "created_at", "data") VALUES (2, 'Coffee, dark', '8', '0.5', NULL, 0, '2024-03-02 09:00:00', NULL);
+`,
+		},
+		{
+			name:    "其他方言的INSERT语句",
+			format:  FormatInsert,
+			dialect: "mysql",
+			want: "INSERT INTO `products` (`id`, `name`, `price`, `discount`, `uid`, `active`, `created_at`, `data`) VALUES (1, 'Tea \"Green\"', '12.5', NULL, '6ba7b810-9dad-11d1-80b4-00c04fd430c8', 1, '2024-03-01 08:30:00', X'00ff');\n" +
+				"INSERT INTO `products` (`id`, `name`, `price`, `discount`, `uid`, `active`, `created_at`, `data`) VALUES (2, 'Coffee, dark', '8', '0.5', NULL, 0, '2024-03-02 09:00:00', NULL);\n",
+		},
+		{
+			name:   "CSV",
+			format: FormatCSV,
+			want: `id,name,price,discount,uid,active,created_at,data
+1,"Tea ""Green""",12.5,,6ba7b810-9dad-11d1-80b4-00c04fd430c8,true,2024-03-01T08:30:00Z,AP8=
+2,"Coffee, dark",8,0.5,,false,2024-03-02T09:00:00Z,
+`,
+		},
+		{
+			name:   "JSON lines",
+			format: FormatJSONL,
+			want: `{"id":1,"name":"Tea \"Green\"","price":"12.5","discount":null,"uid":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","active":true,"created_at":"2024-03-01T08:30:00Z","data":"AP8="}
+{"id":2,"name":"Coffee, dark","price":"8","discount":"0.5","uid":null,"active":false,"created_at":"2024-03-02T09:00:00Z","data":null}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Export(context.Background(), database, &buf, Options{Table: "products", Format: tt.format, Dialect: tt.dialect})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Export(%s) =\n%s\n期望\n%s", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportGoCompiles(t *testing.T) {
+	database := openFixture(t)
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.GeneratorConfig)
+		extra  string   // 同一包中额外的源码
+		want   []string // 测试数据中应包含的内容
+	}{
+		{
+			name:   "默认配置",
+			modify: func(cfg *config.GeneratorConfig) {},
+			want:   []string{"Price:     12.5", "productsPtr[float64](0.5)", `Uid:       productsPtr[string]("6ba7b810-9dad-11d1-80b4-00c04fd430c8")`},
+		},
+		{
+			name: "第三方的定点数和UUID类型",
+			modify: func(cfg *config.GeneratorConfig) {
+				cfg.DecimalType = "github.com/shopspring/decimal.Decimal"
+				cfg.UUIDType = "github.com/google/uuid.UUID"
+			},
+			want: []string{`decimal.RequireFromString("12.5")`, `productsPtr[uuid.UUID](uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))`},
+		},
+		{
+			name: "定点数使用字符串",
+			modify: func(cfg *config.GeneratorConfig) {
+				cfg.DecimalType = "string"
+			},
+			want: []string{`Price:     "12.5"`, `productsPtr[string]("0.5")`},
+		},
+		{
+			name: "无法构造的类型",
+			modify: func(cfg *config.GeneratorConfig) {
+				cfg.Tables = map[string]config.TableOverride{"products": {Types: map[string]string{"price": "Money"}}}
+			},
+			extra: "package model\n\ntype Money struct{ cents int64 }\n",
+			want:  []string{"// 字段 Price 的类型无法由导出的值构造，已省略"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default().Generator
+			tt.modify(&cfg)
+
+			model, err := generator.GenerateStructContent(context.Background(), database, "products", cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			err = Export(context.Background(), database, &buf, Options{Table: "products", Format: FormatGo, Generator: cfg})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sources := map[string]string{"model.go": model, "fixture.go": buf.String()}
+			if tt.extra != "" {
+				sources["extra.go"] = tt.extra
+			}
+			typeCheck(t, sources)
+			for _, s := range tt.want {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("测试数据中没有 %s:\n%s", s, buf.String())
+				}
+			}
+		})
+	}
+}
+
+// stubPackages 类型检查时代替第三方包的最小实现
+var stubPackages = map[string]string{
+	"github.com/google/uuid": `package uuid
+type UUID [16]byte
+func MustParse(s string) UUID { return UUID{} }`,
+	"github.com/shopspring/decimal": `package decimal
+type Decimal struct{ value *int }
+func RequireFromString(s string) Decimal { return Decimal{} }`,
+	"github.com/lib/pq": `package pq
+type StringArray []string
+type Int64Array []int64
+type Float64Array []float64
+type BoolArray []bool
+type ByteaArray [][]byte`,
+	"gorm.io/gorm": `package gorm
+import "time"
+type DeletedAt struct {
+	Time  time.Time
+	Valid bool
+}`,
+}
+
+// stubImporter 标准库按编译结果导入，第三方包使用 stubPackages
+type stubImporter struct {
+	fset *token.FileSet
+	std  types.Importer
+	pkgs map[string]*types.Package
+}
+
+// Import 实现 types.Importer 接口
+func (imp *stubImporter) Import(path string) (*types.Package, error) {
+	src, ok := stubPackages[path]
+	if !ok {
+		return imp.std.Import(path)
+	}
+	if pkg, ok := imp.pkgs[path]; ok {
+		return pkg, nil
+	}
+
+	file, err := parser.ParseFile(imp.fset, path+".go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := (&types.Config{Importer: imp}).Check(path, imp.fset, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, err
+	}
+	imp.pkgs[path] = pkg
+	return pkg, nil
+}
+
+// typeCheck 对同一包中的生成代码做类型检查，确认可以编译
+func typeCheck(t *testing.T, sources map[string]string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for name, src := range sources {
+		file, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			t.Fatalf("%v\n%s", err, src)
+		}
+		files = append(files, file)
+	}
+
+	conf := types.Config{Importer: &stubImporter{fset: fset, std: importer.Default(), pkgs: map[string]*types.Package{}}}
+	if _, err := conf.Check("model", fset, files, nil); err != nil {
+		t.Fatalf("生成的代码无法编译: %v\n%s", err, sources["fixture.go"])
+	}
+}
@@ -0,0 +1,362 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+	"github.com/trade2sql/internal/generator"
+)
+
+// typeParsers 第三方类型由字符串构造的函数，以 导入路径.类型名 为键，解析失败时panic或返回零值
+var typeParsers = map[string]string{
+	"github.com/google/uuid.UUID":           "uuid.MustParse",
+	"github.com/gofrs/uuid.UUID":            "uuid.FromStringOrNil",
+	"github.com/shopspring/decimal.Decimal": "decimal.RequireFromString",
+}
+
+// goWriter 输出生成结构体类型的Go切片字面量，用作测试数据
+type goWriter struct {
+	w           io.Writer
+	packageName string
+	tableName   string
+	structName  string
+	varName     string
+	ptrFunc     string
+	jsonFunc    string
+	fields      []string
+	types       []string
+	jsonStructs []bool
+	parsers     []string
+	typeImports [][]string
+	enumTypes   map[string]bool // 生成的枚举类型，基于字符串，可由字符串转换
+	omitted     map[string]bool // 类型无法由导出的值构造而省略的字段
+	imports     map[string]bool
+	body        bytes.Buffer
+	usesPtr     bool
+	usesJSON    bool
+}
+
+func newGoWriter(w io.Writer, cfg config.GeneratorConfig, table, structName string, columns []db.ColumnInfo) *goWriter {
+	gw := &goWriter{
+		w:           w,
+		packageName: cfg.PackageName,
+		tableName:   table,
+		structName:  structName,
+		varName:     pluralName(structName),
+		ptrFunc:     lowerFirst(structName) + "Ptr",
+		jsonFunc:    lowerFirst(structName) + "JSON",
+		enumTypes:   map[string]bool{},
+		omitted:     map[string]bool{},
+		imports:     map[string]bool{},
+	}
+	if gw.packageName == "" {
+		gw.packageName = "model"
+	}
+
+	for _, col := range columns {
+		goType, imports := generator.FieldType(table, structName, col, cfg)
+		gw.fields = append(gw.fields, generator.TableFieldName(table, col.Name, cfg))
+		gw.types = append(gw.types, goType)
+		gw.jsonStructs = append(gw.jsonStructs, generator.JSONStructType(table, col, cfg) != "")
+		gw.typeImports = append(gw.typeImports, imports)
+		gw.parsers = append(gw.parsers, typeParser(goType, imports))
+		if len(col.EnumValues) > 0 && !col.IsArray && strings.TrimPrefix(goType, "*") == generator.EnumTypeName(table, structName, col, cfg) {
+			gw.enumTypes[strings.TrimPrefix(goType, "*")] = true
+		}
+	}
+
+	return gw
+}
+
+// WriteRow 写出一个结构体字面量
+func (gw *goWriter) WriteRow(values []interface{}) error {
+	gw.body.WriteString("\t{\n")
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		lit, ok := gw.literal(v, gw.types[i], gw.jsonStructs[i], gw.parsers[i])
+		if !ok {
+			gw.omitted[gw.fields[i]] = true
+			continue
+		}
+		for _, imp := range gw.typeImports[i] {
+			gw.imports[imp] = true
+		}
+		fmt.Fprintf(&gw.body, "\t\t%s: %s,\n", gw.fields[i], lit)
+	}
+	gw.body.WriteString("\t},\n")
+
+	return nil
+}
+
+// Close 组装并格式化完整的Go文件
+func (gw *goWriter) Close() error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// 代码由 trade2sql 自动生成\npackage %s\n\n", gw.packageName)
+	if len(gw.imports) > 0 {
+		var imports []string
+		for imp := range gw.imports {
+			imports = append(imports, strconv.Quote(imp))
+		}
+		sort.Strings(imports)
+		fmt.Fprintf(&buf, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	fmt.Fprintf(&buf, "// %s 表 %s 的测试数据\n", gw.varName, gw.tableName)
+	if len(gw.omitted) > 0 {
+		var fields []string
+		for _, field := range gw.fields {
+			if gw.omitted[field] {
+				fields = append(fields, field)
+			}
+		}
+		fmt.Fprintf(&buf, "// 字段 %s 的类型无法由导出的值构造，已省略\n", strings.Join(fields, "、"))
+	}
+	fmt.Fprintf(&buf, "var %s = []%s{\n", gw.varName, gw.structName)
+	buf.Write(gw.body.Bytes())
+	buf.WriteString("}\n")
+	if gw.usesPtr {
+		fmt.Fprintf(&buf, "\n// %s 返回值的指针\nfunc %s[T any](v T) *T {\n\treturn &v\n}\n", gw.ptrFunc, gw.ptrFunc)
+	}
+	if gw.usesJSON {
+		fmt.Fprintf(&buf, "\n// %s 将JSON解码为指定类型\nfunc %s[T any](s string) T {\n\tvar v T\n"+
+			"\tif err := json.Unmarshal([]byte(s), &v); err != nil {\n\t\tpanic(err)\n\t}\n\treturn v\n}\n", gw.jsonFunc, gw.jsonFunc)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = gw.w.Write(src)
+	return err
+}
+
+// literal 将非NULL值格式化为指定Go类型的字面量，无法构造该类型的值时返回false以省略该字段
+// 映射到自定义类型的JSON列通过解码函数构造，parser不为空时通过该函数由字符串构造
+func (gw *goWriter) literal(v interface{}, goType string, jsonStruct bool, parser string) (string, bool) {
+	baseType := strings.TrimPrefix(goType, "*")
+	var lit string
+	if jsonStruct {
+		gw.usesJSON = true
+		gw.imports["encoding/json"] = true
+		lit = fmt.Sprintf("%s[%s](%s)", gw.jsonFunc, baseType, strconv.Quote(fmt.Sprint(textValue(v))))
+	} else if parser != "" {
+		lit = fmt.Sprintf("%s(%s)", parser, strconv.Quote(fmt.Sprint(textValue(v))))
+	} else {
+		var ok bool
+		lit, ok = gw.valueLiteral(v, baseType)
+		if !ok {
+			return "", false
+		}
+	}
+	if baseType != goType {
+		gw.usesPtr = true
+		return fmt.Sprintf("%s[%s](%s)", gw.ptrFunc, baseType, lit), true
+	}
+
+	return lit, true
+}
+
+// valueLiteral 格式化单个值，只处理内置类型和已知的类型，其他类型或值与类型不符时返回false
+func (gw *goWriter) valueLiteral(v interface{}, goType string) (string, bool) {
+	switch {
+	case strings.HasPrefix(goType, "pq.") && strings.HasSuffix(goType, "Array"):
+		return arrayLiteral(v, goType), true
+	case goType == "json.RawMessage" || goType == "datatypes.JSON":
+		return fmt.Sprintf("%s(%s)", goType, strconv.Quote(fmt.Sprint(textValue(v)))), true
+	case goType == "gorm.DeletedAt":
+		lit, ok := gw.valueLiteral(v, "time.Time")
+		return fmt.Sprintf("gorm.DeletedAt{Time: %s, Valid: true}", lit), ok
+	case goType == "[]byte":
+		if b, ok := v.([]byte); ok {
+			return bytesLiteral(b), true
+		}
+		return fmt.Sprintf("[]byte(%s)", strconv.Quote(fmt.Sprint(v))), true
+	case gw.enumTypes[goType]:
+		return fmt.Sprintf("%s(%s)", goType, strconv.Quote(fmt.Sprint(textValue(v)))), true
+	}
+
+	dynamic := goType == "interface{}" || goType == "any"
+	switch val := textValue(v).(type) {
+	case int64:
+		switch {
+		case dynamic:
+			return fmt.Sprintf("int64(%d)", val), true
+		case isBasic(goType, types.IsNumeric):
+			return strconv.FormatInt(val, 10), true
+		case isBasic(goType, types.IsString):
+			return strconv.Quote(strconv.FormatInt(val, 10)), true
+		}
+	case float64:
+		switch {
+		case dynamic:
+			return fmt.Sprintf("float64(%s)", strconv.FormatFloat(val, 'g', -1, 64)), true
+		case isBasic(goType, types.IsFloat) && !math.IsInf(val, 0) && !math.IsNaN(val):
+			return strconv.FormatFloat(val, 'g', -1, 64), true
+		case isBasic(goType, types.IsInteger) && val == math.Trunc(val):
+			return strconv.FormatFloat(val, 'f', -1, 64), true
+		case isBasic(goType, types.IsString):
+			return strconv.Quote(strconv.FormatFloat(val, 'g', -1, 64)), true
+		}
+	case bool:
+		if dynamic || isBasic(goType, types.IsBoolean) {
+			return strconv.FormatBool(val), true
+		}
+	case time.Time:
+		switch {
+		case dynamic || goType == "time.Time":
+			gw.imports["time"] = true
+			t := val.UTC()
+			return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, time.UTC)",
+				t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond()), true
+		case isBasic(goType, types.IsString):
+			return strconv.Quote(val.Format("2006-01-02 15:04:05.999999999")), true
+		}
+	case string:
+		switch {
+		case dynamic || isBasic(goType, types.IsString):
+			return strconv.Quote(val), true
+		case isBasic(goType, types.IsInteger):
+			// DECIMAL等类型以字符串形式读出
+			if _, err := strconv.ParseInt(val, 10, 64); err == nil {
+				return val, true
+			}
+		case isBasic(goType, types.IsFloat):
+			if f, err := strconv.ParseFloat(val, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+				return val, true
+			}
+		case isBasic(goType, types.IsBoolean):
+			if b, err := strconv.ParseBool(val); err == nil {
+				return strconv.FormatBool(b), true
+			}
+		}
+	}
+	return "", false
+}
+
+// isBasic 判断类型名是否为具有指定属性的内置基本类型，如 types.IsInteger
+func isBasic(goType string, info types.BasicInfo) bool {
+	obj := types.Universe.Lookup(goType)
+	if obj == nil {
+		return false
+	}
+	basic, ok := obj.Type().(*types.Basic)
+	return ok && basic.Info()&info != 0
+}
+
+// textValue 将驱动返回的字节切片转换为字符串
+func textValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
+
+// arrayLiteral 借助lib/pq解析PostgreSQL数组并格式化为对应的切片字面量
+func arrayLiteral(v interface{}, goType string) string {
+	var elems []string
+	var err error
+	switch goType {
+	case "pq.Int64Array":
+		var a pq.Int64Array
+		err = a.Scan(v)
+		for _, e := range a {
+			elems = append(elems, strconv.FormatInt(e, 10))
+		}
+	case "pq.Float64Array":
+		var a pq.Float64Array
+		err = a.Scan(v)
+		for _, e := range a {
+			elems = append(elems, strconv.FormatFloat(e, 'g', -1, 64))
+		}
+	case "pq.BoolArray":
+		var a pq.BoolArray
+		err = a.Scan(v)
+		for _, e := range a {
+			elems = append(elems, strconv.FormatBool(e))
+		}
+	case "pq.ByteaArray":
+		var a pq.ByteaArray
+		err = a.Scan(v)
+		for _, e := range a {
+			elems = append(elems, bytesLiteral(e))
+		}
+	default:
+		var a pq.StringArray
+		err = a.Scan(v)
+		for _, e := range a {
+			elems = append(elems, strconv.Quote(e))
+		}
+	}
+	if err != nil {
+		return fmt.Sprintf("%s{}", goType)
+	}
+
+	return fmt.Sprintf("%s{%s}", goType, strings.Join(elems, ", "))
+}
+
+// bytesLiteral 格式化字节切片，可打印文本使用字符串形式
+func bytesLiteral(b []byte) string {
+	if utf8.Valid(b) && strings.IndexFunc(string(b), func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return fmt.Sprintf("[]byte(%s)", strconv.Quote(string(b)))
+	}
+
+	parts := make([]string, len(b))
+	for i, c := range b {
+		parts[i] = fmt.Sprintf("0x%02x", c)
+	}
+	return "[]byte{" + strings.Join(parts, ", ") + "}"
+}
+
+// typeParser 返回字段类型为 typeParsers 中的类型时对应的构造函数
+func typeParser(goType string, imports []string) string {
+	_, name, ok := strings.Cut(strings.TrimPrefix(goType, "*"), ".")
+	if !ok {
+		return ""
+	}
+	for _, imp := range imports {
+		if parser, ok := typeParsers[imp+"."+name]; ok {
+			return parser
+		}
+	}
+	return ""
+}
+
+// pluralName 返回结构体名的复数形式，用作测试数据的变量名，如 User 对应 Users
+// 结构体名已以s结尾时无法区分单复数，加 List 后缀以免与类型同名
+func pluralName(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "s"):
+		return name + "List"
+	case strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "z") ||
+		strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
+
+// lowerFirst 首字母小写
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
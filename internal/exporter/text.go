@@ -0,0 +1,134 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/trade2sql/internal/db"
+)
+
+// insertWriter 输出INSERT语句
+type insertWriter struct {
+	w       *bufio.Writer
+	dialect db.Dialect
+	prefix  string
+}
+
+func newInsertWriter(w io.Writer, dialect db.Dialect, quotedTable string, columns []db.ColumnInfo) *insertWriter {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = dialect.QuoteIdent(col.Name)
+	}
+
+	return &insertWriter{
+		w:       bufio.NewWriter(w),
+		dialect: dialect,
+		prefix:  fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quotedTable, strings.Join(quoted, ", ")),
+	}
+}
+
+// WriteRow 写出一条INSERT语句
+func (iw *insertWriter) WriteRow(values []interface{}) error {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = db.Literal(iw.dialect, v)
+	}
+
+	_, err := fmt.Fprintf(iw.w, "%s(%s);\n", iw.prefix, strings.Join(literals, ", "))
+	return err
+}
+
+// Close 刷新缓冲区
+func (iw *insertWriter) Close() error {
+	return iw.w.Flush()
+}
+
+// csvWriter 输出CSV，首行为列名
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []db.ColumnInfo) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+
+	return cw, cw.w.Write(header)
+}
+
+// WriteRow 写出一行CSV
+func (cw *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch val := v.(type) {
+		case nil:
+			record[i] = ""
+		case time.Time:
+			record[i] = val.Format(time.RFC3339Nano)
+		case []byte:
+			record[i] = base64.StdEncoding.EncodeToString(val)
+		default:
+			record[i] = fmt.Sprint(val)
+		}
+	}
+
+	return cw.w.Write(record)
+}
+
+// Close 刷新缓冲区
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// jsonlWriter 输出JSON Lines，每行一个对象，键顺序与列顺序一致
+type jsonlWriter struct {
+	w    *bufio.Writer
+	keys [][]byte
+}
+
+func newJSONLWriter(w io.Writer, columns []db.ColumnInfo) *jsonlWriter {
+	keys := make([][]byte, len(columns))
+	for i, col := range columns {
+		keys[i], _ = json.Marshal(col.Name)
+	}
+
+	return &jsonlWriter{
+		w:    bufio.NewWriter(w),
+		keys: keys,
+	}
+}
+
+// WriteRow 写出一行JSON对象
+func (jw *jsonlWriter) WriteRow(values []interface{}) error {
+	jw.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			jw.w.WriteByte(',')
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		jw.w.Write(jw.keys[i])
+		jw.w.WriteByte(':')
+		jw.w.Write(data)
+	}
+	jw.w.WriteString("}\n")
+
+	return nil
+}
+
+// Close 刷新缓冲区
+func (jw *jsonlWriter) Close() error {
+	return jw.w.Flush()
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// BaseModel 返回表嵌入的基础结构体和去掉基础结构体的列之后的其余列
+// 按配置顺序使用第一个列全部存在于表中的基础结构体，没有匹配时返回nil和原来的列
+func BaseModel(columns []db.ColumnInfo, cfg config.GeneratorConfig) (*config.BaseModel, []db.ColumnInfo) {
+	for i, base := range cfg.BaseModels {
+		if len(base.Columns) == 0 || !hasColumns(columns, base.Columns) {
+			continue
+		}
+
+		var rest []db.ColumnInfo
+		for _, col := range columns {
+			if !containsFold(base.Columns, col.Name) {
+				rest = append(rest, col)
+			}
+		}
+		return &cfg.BaseModels[i], rest
+	}
+	return nil, columns
+}
+
+// hasColumns 检查表是否包含全部指定的列
+func hasColumns(columns []db.ColumnInfo, names []string) bool {
+	for _, name := range names {
+		found := false
+		for _, col := range columns {
+			if strings.EqualFold(col.Name, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold 检查切片中是否包含指定字符串，不区分大小写
+func containsFold(slice []string, s string) bool {
+	for _, item := range slice {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSoftDeleteColumn 判断列是否为生成 gorm.DeletedAt 的软删除列
+func isSoftDeleteColumn(col db.ColumnInfo, cfg config.GeneratorConfig) bool {
+	column := cfg.SoftDeleteColumn()
+	if column == "" || !strings.EqualFold(col.Name, column) {
+		return false
+	}
+	return db.ColumnKind(col) == db.KindTime && cfg.HasTag("gorm")
+}
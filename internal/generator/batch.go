@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// GenerateFiles 批量生成多张表的结构体和共用类型，返回输出文件相对路径到内容的映射
+// 表列表只读取一次，各表的列信息由最多workers个协程并发读取
+func GenerateFiles(ctx context.Context, database *db.Database, tableNames []string, cfg config.GeneratorConfig, workers int) (map[string]string, error) {
+	tables, err := database.GetTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取表列表失败: %v", err)
+	}
+
+	structs := make([]structCode, len(tableNames))
+	types := make([]map[string]string, len(tableNames))
+	err = db.EachTable(ctx, tableNames, workers, func(ctx context.Context, i int, name string) error {
+		columns, err := database.GetTableInfo(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取表 %s 结构失败: %v", name, err)
+		}
+
+		structs[i], err = renderStruct(database, name, database.FindTable(tables, name), columns, cfg)
+		if err != nil {
+			return fmt.Errorf("生成表 %s 的结构体失败: %v", name, err)
+		}
+		types[i], err = renderTypeFiles(database, name, columns, cfg)
+		if err != nil {
+			return fmt.Errorf("生成表 %s 的类型失败: %v", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 按输出文件分组，single 布局下同一包中的结构体写入同一个文件
+	// 共用类型可能被多张表引用，按文件路径去重
+	var fileNames []string
+	grouped := map[string][]structCode{}
+	files := map[string]string{}
+	for i, name := range tableNames {
+		fileName := batchFileName(database, name, cfg)
+		if _, ok := grouped[fileName]; !ok {
+			fileNames = append(fileNames, fileName)
+		}
+		grouped[fileName] = append(grouped[fileName], structs[i])
+		for typeFile, content := range types[i] {
+			files[filepath.Join(filepath.Dir(fileName), typeFile)] = content
+		}
+	}
+
+	for _, fileName := range fileNames {
+		content, err := renderFile(grouped[fileName])
+		if err != nil {
+			return nil, err
+		}
+		files[fileName] = content
+	}
+	return files, nil
+}
+
+// GenerateStructs 批量生成多张表的结构体并写入目录，返回写入的文件路径
+// 已有的文件中有不是 trade2sql 生成的文件时不写入任何文件，force为true时仍然覆盖
+func GenerateStructs(ctx context.Context, database *db.Database, tableNames []string, dir string, cfg config.GeneratorConfig, workers int, force bool) ([]string, error) {
+	files, err := GenerateFiles(ctx, database, tableNames, cfg, workers)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string, len(files))
+	for name, content := range files {
+		paths[filepath.Join(dir, name)] = content
+	}
+	return WriteFiles(paths, force)
+}
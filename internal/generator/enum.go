@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// 枚举类型模板
+const enumTemplate = `{{range .Enums}}
+// {{.TypeName}} {{.Comment}}
+type {{.TypeName}} string
+
+// {{.TypeName}} 的取值
+const (
+{{- $type := .TypeName}}
+{{- range .Values}}
+	{{.Name}} {{$type}} = {{.Literal}}
+{{- end}}
+)
+{{if .IsSet}}
+// Valid 判断取值是否合法，集合的多个成员以逗号分隔
+func (e {{.TypeName}}) Valid() bool {
+	if e == "" {
+		return true
+	}
+	for _, v := range strings.Split(string(e), ",") {
+		switch {{.TypeName}}(v) {
+		case {{.ConstList}}:
+		default:
+			return false
+		}
+	}
+	return true
+}
+{{else}}
+// Valid 判断取值是否合法
+func (e {{.TypeName}}) Valid() bool {
+	switch e {
+	case {{.ConstList}}:
+		return true
+	}
+	return false
+}
+{{end}}{{if $.Scanner}}
+// Scan 实现 sql.Scanner 接口
+func (e *{{.TypeName}}) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		*e = {{.TypeName}}(v)
+	case []byte:
+		*e = {{.TypeName}}(v)
+	default:
+		return fmt.Errorf("无法将 %T 转换为 {{.TypeName}}", src)
+	}
+	// MySQL非严格模式下写入非法值时存为空字符串，读出时不视为错误
+	if *e != "" && !e.Valid() {
+		return fmt.Errorf("{{.TypeName}} 取值不合法: %q", string(*e))
+	}
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口
+func (e {{.TypeName}}) Value() (driver.Value, error) {
+	if !e.Valid() {
+		return nil, fmt.Errorf("{{.TypeName}} 取值不合法: %q", string(e))
+	}
+	return string(e), nil
+}
+{{end}}{{end}}`
+
+// EnumData 枚举类型数据
+type EnumData struct {
+	TypeName  string
+	Comment   string
+	IsSet     bool
+	Values    []EnumValue
+	ConstList string
+	Shared    bool // 是否为可被多张表共用的PostgreSQL枚举类型
+	FileName  string
+}
+
+// EnumValue 枚举常量
+type EnumValue struct {
+	Name    string
+	Literal string
+}
+
+// isEnumColumn 判断列是否为枚举或集合类型，枚举数组仍按字符串数组处理
+func isEnumColumn(col db.ColumnInfo) bool {
+	return len(col.EnumValues) > 0 && !col.IsArray
+}
+
+// EnumTypeName 返回枚举列对应的Go类型名
+// PostgreSQL枚举按类型名命名，MySQL的ENUM/SET按结构体名加字段名命名，字段名包含单表设置中的重命名
+func EnumTypeName(tableName, structName string, col db.ColumnInfo, cfg config.GeneratorConfig) string {
+	if col.EnumName != "" {
+		return toUpperCamelCase(col.EnumName)
+	}
+	return structName + TableFieldName(tableName, col.Name, cfg)
+}
+
+// tableEnums 收集表中枚举列对应的枚举类型，同名类型只保留一个
+func tableEnums(tableName, structName string, columns []db.ColumnInfo, cfg config.GeneratorConfig) []EnumData {
+	var enums []EnumData
+	seen := map[string]bool{}
+	for _, col := range columns {
+		if !isEnumColumn(col) {
+			continue
+		}
+
+		typeName := EnumTypeName(tableName, structName, col, cfg)
+		if seen[typeName] {
+			continue
+		}
+		seen[typeName] = true
+
+		enum := EnumData{
+			TypeName: typeName,
+			IsSet:    col.IsSet,
+			Shared:   col.EnumName != "",
+		}
+		switch {
+		case enum.Shared:
+			enum.Comment = fmt.Sprintf("对应枚举类型 %s", col.EnumName)
+			enum.FileName = fmt.Sprintf("%s_enum.go", col.EnumName)
+		case col.IsSet:
+			enum.Comment = fmt.Sprintf("表 %s 中 %s 列的集合类型", tableName, col.Name)
+		default:
+			enum.Comment = fmt.Sprintf("表 %s 中 %s 列的枚举类型", tableName, col.Name)
+		}
+
+		names := map[string]bool{}
+		var consts []string
+		for _, v := range col.EnumValues {
+			name := enumConstName(typeName, v)
+			for i := 2; names[name]; i++ {
+				name = fmt.Sprintf("%s%d", enumConstName(typeName, v), i)
+			}
+			names[name] = true
+
+			enum.Values = append(enum.Values, EnumValue{Name: name, Literal: strconv.Quote(v)})
+			consts = append(consts, name)
+		}
+		enum.ConstList = strings.Join(consts, ", ")
+
+		enums = append(enums, enum)
+	}
+
+	return enums
+}
+
+// enumConstName 返回枚举取值对应的常量名
+func enumConstName(typeName, value string) string {
+	name := toUpperCamelCase(value)
+	if name == "" {
+		name = "Empty"
+	}
+	return typeName + name
+}
+
+// renderEnums 渲染枚举类型代码并返回需要导入的包
+func renderEnums(enums []EnumData, cfg config.GeneratorConfig) (string, []string, error) {
+	if len(enums) == 0 {
+		return "", nil, nil
+	}
+
+	var imports []string
+	for _, enum := range enums {
+		if enum.IsSet && !containsString(imports, `"strings"`) {
+			imports = append(imports, `"strings"`)
+		}
+	}
+	if cfg.EnumScanner {
+		imports = append(imports, `"database/sql/driver"`, `"fmt"`)
+	}
+
+	tmpl, err := template.New("enum").Parse(enumTemplate)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, struct {
+		Enums   []EnumData
+		Scanner bool
+	}{enums, cfg.EnumScanner})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return buf.String(), imports, nil
+}
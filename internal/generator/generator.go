@@ -2,8 +2,10 @@ package generator
 
 import (
 	"bytes"
+	"context"
 	"fmt"
-	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
@@ -12,8 +14,8 @@ import (
 	"github.com/trade2sql/internal/db"
 )
 
-// 结构体模板
-const structTemplate = `// 代码由 trade2sql 自动生成
+// 文件模板，一个文件中可以有多个结构体
+const fileTemplate = GeneratedHeader + `
 package {{.PackageName}}
 
 {{if .Imports}}
@@ -22,21 +24,44 @@ import (
 	{{end}}
 )
 {{end}}
+// trade2sql:user-begin imports
+// trade2sql:user-end imports
+{{range .Structs}}{{.}}{{end}}`
 
-// {{.StructName}} 对应数据库表 {{.TableName}}
-type {{.StructName}} struct {
-{{range .Fields}}	{{.Name}} {{.Type}} {{.Tag}} {{if .Comment}}// {{.Comment}}{{end}}
+// 结构体模板，用户区域以结构体名区分，同一文件中有多个结构体时互不影响
+const structTemplate = `
+// {{.StructName}} {{if .TableComment}}{{.TableComment}}，{{end}}对应数据库{{if .IsView}}视图{{else}}表{{end}} {{.TableName}}
+{{if .IsView}}// 视图为只读，该结构体仅用于查询
+{{end}}type {{.StructName}} struct {
+{{range .Embeds}}	{{.}}
+{{end}}{{range .Fields}}	{{.Name}} {{.Type}} {{.Tag}} {{if .Comment}}// {{.Comment}}{{end}}
 {{end}}
+	// trade2sql:user-begin {{.StructName}}.fields
+	// trade2sql:user-end {{.StructName}}.fields
 }
+{{.EnumCode}}
+// trade2sql:user-begin {{.StructName}}.methods
+// trade2sql:user-end {{.StructName}}.methods
 `
 
-// TemplateData 模板数据
-type TemplateData struct {
+// FileData 文件模板数据
+type FileData struct {
 	PackageName string
 	Imports     []string
-	StructName  string
-	TableName   string
-	Fields      []FieldData
+	Structs     []string // 各结构体的代码
+}
+
+// TemplateData 模板数据
+type TemplateData struct {
+	PackageName  string
+	Imports      []string
+	StructName   string
+	TableName    string
+	TableComment string
+	IsView       bool
+	Fields       []FieldData
+	EnumCode     string   // 表中枚举列对应的类型定义
+	Embeds       []string // 嵌入的类型
 }
 
 // FieldData 字段数据
@@ -48,31 +73,107 @@ type FieldData struct {
 }
 
 // GenerateStructContent 生成结构体内容并返回字符串
-func GenerateStructContent(database *db.Database, tableName string, cfg config.GeneratorConfig) (string, error) {
-	columns, err := database.GetTableInfo(tableName)
+func GenerateStructContent(ctx context.Context, database *db.Database, tableName string, cfg config.GeneratorConfig) (string, error) {
+	table, err := database.GetTable(ctx, tableName)
+	if err != nil {
+		return "", err
+	}
+
+	columns, err := database.GetTableInfo(ctx, tableName)
+	if err != nil {
+		return "", err
+	}
+
+	code, err := renderStruct(database, tableName, table, columns, cfg)
+	if err != nil {
+		return "", err
+	}
+	return renderFile([]structCode{code})
+}
+
+// structCode 渲染好的结构体代码及其所在的包和需要的导入
+type structCode struct {
+	PackageName string
+	Imports     []string
+	Code        string
+}
+
+// renderFile 将同一包中的结构体渲染为一个文件，导入合并为一个导入块
+func renderFile(structs []structCode) (string, error) {
+	data := FileData{Imports: []string{}}
+	for _, s := range structs {
+		data.PackageName = s.PackageName
+		for _, imp := range s.Imports {
+			if !containsString(data.Imports, imp) {
+				data.Imports = append(data.Imports, imp)
+			}
+		}
+		data.Structs = append(data.Structs, s.Code)
+	}
+
+	tmpl, err := template.New("file").Parse(fileTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
 		return "", err
 	}
 
+	return buf.String(), nil
+}
+
+// renderStruct 根据已读取的表信息和列信息渲染结构体
+func renderStruct(database *db.Database, tableName string, table db.TableInfo, columns []db.ColumnInfo, cfg config.GeneratorConfig) (structCode, error) {
+	override := tableOverride(tableName, cfg)
+	columns = FilterColumns(tableName, columns, cfg)
+
 	// 准备模板数据
+	packageName, structName := TableNaming(database, tableName, cfg)
 	data := TemplateData{
-		PackageName: cfg.PackageName,
-		StructName:  toUpperCamelCase(tableName),
-		TableName:   tableName,
-		Imports:     []string{},
+		PackageName:  packageName,
+		StructName:   structName,
+		TableName:    tableName,
+		TableComment: commentText(table.Comment),
+		IsView:       table.IsView(),
+		Imports:      []string{},
+	}
+
+	// 嵌入的类型，基础结构体在最前面，其包含的列不再单独生成字段
+	embeds, embedImports := embedFields(override)
+	base, columns := BaseModel(columns, cfg)
+	if base != nil {
+		goType, importPath := resolveType(strings.TrimSpace(base.Type))
+		if !containsString(embeds, goType) {
+			embeds = append([]string{goType}, embeds...)
+		}
+		if importPath != "" {
+			embedImports = append(embedImports, importPath)
+		}
+	}
+	data.Embeds = embeds
+	for _, imp := range embedImports {
+		if !containsString(data.Imports, strconv.Quote(imp)) {
+			data.Imports = append(data.Imports, strconv.Quote(imp))
+		}
 	}
 
 	// 处理字段
 	for _, col := range columns {
+		goType, imports := FieldType(tableName, structName, col, cfg)
 		field := FieldData{
-			Name:    toUpperCamelCase(col.Name),
-			Type:    mapSQLTypeToGoType(col.Type, col.IsNullable),
-			Comment: col.Comment,
+			Name:    TableFieldName(tableName, col.Name, cfg),
+			Type:    goType,
+			Comment: commentText(col.Comment),
 		}
 
 		// 添加必要的导入
-		if strings.Contains(field.Type, "time.Time") && !containsString(data.Imports, `"time"`) {
-			data.Imports = append(data.Imports, `"time"`)
+		for _, imp := range imports {
+			if !containsString(data.Imports, strconv.Quote(imp)) {
+				data.Imports = append(data.Imports, strconv.Quote(imp))
+			}
 		}
 
 		// 生成标签
@@ -83,6 +184,12 @@ func GenerateStructContent(database *db.Database, tableName string, cfg config.G
 		if col.IsPrimary {
 			tags = append(tags, `primary:"true"`)
 		}
+		if data.IsView {
+			tags = append(tags, `readonly:"true"`)
+		}
+		if extra := strings.TrimSpace(override.Tags[col.Name]); extra != "" {
+			tags = append(tags, extra)
+		}
 
 		if len(tags) > 0 {
 			field.Tag = fmt.Sprintf("`%s`", strings.Join(tags, " "))
@@ -91,35 +198,125 @@ func GenerateStructContent(database *db.Database, tableName string, cfg config.G
 		data.Fields = append(data.Fields, field)
 	}
 
+	// 生成枚举类型，PostgreSQL枚举类型由 GenerateTypeFiles 单独输出
+	var enums []EnumData
+	for _, enum := range tableEnums(tableName, structName, enumColumns(columns, override), cfg) {
+		if !enum.Shared {
+			enums = append(enums, enum)
+		}
+	}
+	enumCode, enumImports, err := renderEnums(enums, cfg)
+	if err != nil {
+		return structCode{}, err
+	}
+	data.EnumCode = enumCode
+	for _, imp := range enumImports {
+		if !containsString(data.Imports, imp) {
+			data.Imports = append(data.Imports, imp)
+		}
+	}
+
 	// 渲染模板
 	tmpl, err := template.New("struct").Parse(structTemplate)
 	if err != nil {
-		return "", err
+		return structCode{}, err
 	}
 
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return "", err
+		return structCode{}, err
 	}
 
-	return buf.String(), nil
+	return structCode{PackageName: data.PackageName, Imports: data.Imports, Code: buf.String()}, nil
+}
+
+// StructFiles 生成单张表的结构体和共用类型，返回输出文件路径到内容的映射，不写入文件
+func StructFiles(ctx context.Context, database *db.Database, tableName, outputPath string, cfg config.GeneratorConfig) (map[string]string, error) {
+	content, err := GenerateStructContent(ctx, database, tableName, cfg)
+	if err != nil {
+		return nil, err
+	}
+	types, err := GenerateTypeFiles(ctx, database, tableName, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{outputPath: content}
+	for name, typeContent := range types {
+		files[filepath.Join(filepath.Dir(outputPath), name)] = typeContent
+	}
+	return files, nil
 }
 
-// GenerateStruct 生成结构体并写入文件
-func GenerateStruct(database *db.Database, tableName, outputPath string, cfg config.GeneratorConfig) error {
-	content, err := GenerateStructContent(database, tableName, cfg)
+// GenerateStruct 生成结构体并写入文件，共用的类型写入同目录下的单独文件
+// 保留已有文件中用户区域的内容，已有的文件不是 trade2sql 生成的时拒绝覆盖，force为true时仍然覆盖
+// 有文件不能写入时不写入任何文件
+func GenerateStruct(ctx context.Context, database *db.Database, tableName, outputPath string, cfg config.GeneratorConfig, force bool) error {
+	files, err := StructFiles(ctx, database, tableName, outputPath, cfg)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(outputPath, []byte(content), 0644)
+	_, err = WriteFiles(files, force)
+	return err
+}
+
+// OutputFileName 返回表对应的输出文件相对路径，文件名由文件名模板或单表设置确定
+// 模式映射到独立包或按前缀分组时，文件位于以包名命名的子目录中
+func OutputFileName(database *db.Database, tableName string, cfg config.GeneratorConfig) string {
+	schema, table := database.SplitTableName(tableName)
+	_, structName := TableNaming(database, tableName, cfg)
+
+	fileName, err := FileName(cfg.FilePattern, FileNameData{Table: table, Schema: schema, Struct: structName})
+	if err != nil {
+		// 配置文件中的模板加载时已校验，这里只会是代码中设置的无效模板
+		fileName = fmt.Sprintf("%s_model.go", table)
+	}
+	if name := tableOverride(tableName, cfg).OutputFile; name != "" {
+		fileName = name
+	}
+	return filepath.Join(tableDir(schema, table, cfg), fileName)
+}
+
+// TableNaming 根据模式映射、输出布局和单表设置确定表对应的包名和结构体名
+func TableNaming(database *db.Database, tableName string, cfg config.GeneratorConfig) (packageName, structName string) {
+	schema, table := database.SplitTableName(tableName)
+
+	packageName = cfg.PackageName
+	if pkg, ok := cfg.SchemaPackages[schema]; ok {
+		packageName = pkg
+	}
+	if pkg := prefixPackage(table, cfg); pkg != "" {
+		packageName = pkg
+	}
+
+	structName = StructName(table)
+	if prefix, ok := cfg.SchemaPrefixes[schema]; ok {
+		structName = toUpperCamelCase(prefix) + structName
+	}
+	if name := tableOverride(tableName, cfg).StructName; name != "" {
+		structName = name
+	}
+
+	return packageName, structName
+}
+
+// StructName 返回表对应的结构体名
+func StructName(tableName string) string {
+	return toUpperCamelCase(tableName)
+}
+
+// FieldName 返回列对应的字段名
+func FieldName(columnName string) string {
+	return toUpperCamelCase(columnName)
 }
 
 // toUpperCamelCase 转换为大驼峰命名
 func toUpperCamelCase(s string) string {
+	// 除字母和数字外的字符都视为单词分隔符，保证结果是合法的标识符
 	words := strings.FieldsFunc(s, func(r rune) bool {
-		return r == '_' || r == ' '
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
 	})
 
 	for i, word := range words {
@@ -133,48 +330,9 @@ func toUpperCamelCase(s string) string {
 	return strings.Join(words, "")
 }
 
-// mapSQLTypeToGoType 将SQL类型映射到Go类型
-func mapSQLTypeToGoType(sqlType string, isNullable bool) string {
-	sqlType = strings.ToLower(sqlType)
-
-	// 基本类型映射
-	var goType string
-	switch {
-	case strings.Contains(sqlType, "int"):
-		goType = "int64"
-	case strings.Contains(sqlType, "float") || strings.Contains(sqlType, "double") || strings.Contains(sqlType, "decimal"):
-		goType = "float64"
-	case strings.Contains(sqlType, "bool"):
-		goType = "bool"
-	case strings.Contains(sqlType, "char") || strings.Contains(sqlType, "text") || strings.Contains(sqlType, "varchar"):
-		goType = "string"
-	case strings.Contains(sqlType, "date") || strings.Contains(sqlType, "time"):
-		goType = "time.Time"
-	case strings.Contains(sqlType, "blob") || strings.Contains(sqlType, "binary"):
-		goType = "[]byte"
-	default:
-		goType = "interface{}"
-	}
-
-	// 处理可空类型
-	if isNullable {
-		switch goType {
-		case "int64":
-			return "*int64"
-		case "float64":
-			return "*float64"
-		case "bool":
-			return "*bool"
-		case "time.Time":
-			return "*time.Time"
-		case "string":
-			return "*string"
-		default:
-			return goType
-		}
-	}
-
-	return goType
+// commentText 将多行注释合并为一行，以便放在单行注释中
+func commentText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
 }
 
 // containsString 检查字符串切片是否包含指定字符串
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"bytes"
+	"go/token"
+	"go/types"
+	"strings"
+	"text/template"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// JSON结构体的 Scan/Value 方法模板
+const jsonScannerTemplate = `
+// Scan 实现 sql.Scanner 接口，将JSON解码到 {{.}}
+func (v *{{.}}) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, v)
+	case string:
+		return json.Unmarshal([]byte(data), v)
+	default:
+		return fmt.Errorf("无法将 %T 转换为 {{.}}", src)
+	}
+}
+
+// Value 实现 driver.Valuer 接口，将 {{.}} 编码为JSON
+func (v {{.}}) Value() (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+`
+
+// JSONStructType 返回JSON列映射到的当前包中的自定义类型名，不是自定义类型时返回空字符串
+// 这些类型由用户定义，生成器为其生成 Scan/Value 方法
+func JSONStructType(tableName string, col db.ColumnInfo, cfg config.GeneratorConfig) string {
+	if !isJSONColumn(col) {
+		return ""
+	}
+
+	goType := cfg.JSONTypes[tableName+"."+col.Name]
+	if goType == "" {
+		goType = cfg.JSONType
+	}
+	// 带包名的类型和内置类型无法定义方法
+	if !token.IsIdentifier(goType) || types.Universe.Lookup(goType) != nil {
+		return ""
+	}
+	return goType
+}
+
+// jsonScannerFileName 返回自定义JSON类型的方法所在的文件名
+func jsonScannerFileName(typeName string) string {
+	return strings.ToLower(typeName) + "_json.go"
+}
+
+// renderJSONScanner 渲染自定义JSON类型的 Scan/Value 方法
+func renderJSONScanner(typeName string) (string, []string, error) {
+	tmpl, err := template.New("jsonScanner").Parse(jsonScannerTemplate)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, typeName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return buf.String(), []string{`"database/sql/driver"`, `"encoding/json"`, `"fmt"`}, nil
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// FileNameData 文件名模板的数据
+type FileNameData struct {
+	Table  string // 不带模式的表名
+	Schema string // 模式名，没有模式时为空
+	Struct string // 结构体名
+}
+
+// FileName 按文件名模板返回表的文件名，模板为空时使用 config.DefaultFilePattern
+func FileName(pattern string, data FileNameData) (string, error) {
+	if pattern == "" {
+		pattern = config.DefaultFilePattern
+	}
+
+	tmpl, err := template.New("file").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// prefixPackage 返回 prefix 布局下表所属的子包名，表名没有可用作包名的前缀时返回空
+func prefixPackage(table string, cfg config.GeneratorConfig) string {
+	if cfg.Layout != config.LayoutPrefix {
+		return ""
+	}
+
+	prefix, _, ok := strings.Cut(table, "_")
+	prefix = strings.ToLower(prefix)
+	if !ok || !token.IsIdentifier(prefix) || token.IsKeyword(prefix) {
+		return ""
+	}
+	for _, r := range prefix {
+		if r > 127 {
+			return ""
+		}
+	}
+	return prefix
+}
+
+// tableDir 返回表的输出子目录，依次为模式对应的包和 prefix 布局下的前缀子包
+func tableDir(schema, table string, cfg config.GeneratorConfig) string {
+	return filepath.Join(cfg.SchemaPackages[schema], prefixPackage(table, cfg))
+}
+
+// batchFileName 返回批量生成时表的输出文件相对路径
+// single 布局下同一包中的结构体写入同一个文件，单表设置了输出文件名的表仍单独输出
+func batchFileName(database *db.Database, tableName string, cfg config.GeneratorConfig) string {
+	if cfg.Layout != config.LayoutSingle || tableOverride(tableName, cfg).OutputFile != "" {
+		return OutputFileName(database, tableName, cfg)
+	}
+
+	schema, table := database.SplitTableName(tableName)
+	fileName := cfg.SingleFile
+	if fileName == "" {
+		fileName = config.DefaultSingleFile
+	}
+	return filepath.Join(tableDir(schema, table, cfg), fileName)
+}
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+func TestFileName(t *testing.T) {
+	data := FileNameData{Table: "user_account", Schema: "sales", Struct: "UserAccount"}
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+		wantErr bool
+	}{
+		{name: "默认模板", pattern: "", want: "user_account_model.go"},
+		{name: "结构体名", pattern: "{{.Struct}}.go", want: "UserAccount.go"},
+		{name: "模式和表名", pattern: "{{.Schema}}_{{.Table}}.go", want: "sales_user_account.go"},
+		{name: "模板函数", pattern: `{{printf "%s_gen.go" .Table}}`, want: "user_account_gen.go"},
+		{name: "未知字段", pattern: "{{.Name}}.go", wantErr: true},
+		{name: "语法错误", pattern: "{{.Table", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FileName(tt.pattern, data)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FileName(%q) = %q，期望返回错误", tt.pattern, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("FileName(%q) = %q，期望 %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+// openSQLite 打开内存中的SQLite数据库，用于不需要模式的文件名测试
+func openSQLite(t *testing.T) *db.Database {
+	database, err := db.Connect(context.Background(), "sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestTableDir(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		table  string
+		cfg    config.GeneratorConfig
+		want   string
+	}{
+		{name: "默认", table: "users", want: ""},
+		{name: "模式映射到子包", schema: "sales", table: "orders", cfg: config.GeneratorConfig{SchemaPackages: map[string]string{"sales": "sales"}}, want: "sales"},
+		{name: "未映射的模式", schema: "audit", table: "logs", cfg: config.GeneratorConfig{SchemaPackages: map[string]string{"sales": "sales"}}, want: ""},
+		{name: "按前缀分组", table: "user_account", cfg: config.GeneratorConfig{Layout: config.LayoutPrefix}, want: "user"},
+		{name: "模式和前缀", schema: "sales", table: "order_item", cfg: config.GeneratorConfig{Layout: config.LayoutPrefix, SchemaPackages: map[string]string{"sales": "sales"}}, want: filepath.Join("sales", "order")},
+		{name: "前缀不能用作包名", table: "type_codes", cfg: config.GeneratorConfig{Layout: config.LayoutPrefix}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tableDir(tt.schema, tt.table, tt.cfg); got != tt.want {
+				t.Errorf("tableDir(%q, %q) = %q，期望 %q", tt.schema, tt.table, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFileName(t *testing.T) {
+	database := openSQLite(t)
+
+	tests := []struct {
+		name  string
+		table string
+		cfg   config.GeneratorConfig
+		want  string
+	}{
+		{
+			name:  "默认",
+			table: "users",
+			want:  "users_model.go",
+		},
+		{
+			name:  "按前缀分组",
+			table: "user_account",
+			cfg:   config.GeneratorConfig{Layout: config.LayoutPrefix, FilePattern: "{{.Struct}}.go"},
+			want:  filepath.Join("user", "UserAccount.go"),
+		},
+		{
+			name:  "前缀不能用作包名",
+			table: "type_codes",
+			cfg:   config.GeneratorConfig{Layout: config.LayoutPrefix},
+			want:  "type_codes_model.go",
+		},
+		{
+			name:  "单表设置的文件名优先",
+			table: "users",
+			cfg: config.GeneratorConfig{
+				FilePattern: "{{.Struct}}.go",
+				Tables:      map[string]config.TableOverride{"users": {OutputFile: "account.go"}},
+			},
+			want: "account.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OutputFileName(database, tt.table, tt.cfg); got != tt.want {
+				t.Errorf("OutputFileName(%q) = %q，期望 %q", tt.table, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchFileName(t *testing.T) {
+	database := openSQLite(t)
+
+	cfg := config.GeneratorConfig{
+		Layout:     config.LayoutSingle,
+		SingleFile: "tables.go",
+		Tables:     map[string]config.TableOverride{"audit_log": {OutputFile: "audit.go"}},
+	}
+	tests := []struct {
+		table string
+		want  string
+	}{
+		{"users", "tables.go"},
+		{"orders", "tables.go"},
+		{"audit_log", "audit.go"},
+	}
+
+	for _, tt := range tests {
+		if got := batchFileName(database, tt.table, cfg); got != tt.want {
+			t.Errorf("batchFileName(%q) = %q，期望 %q", tt.table, got, tt.want)
+		}
+	}
+}
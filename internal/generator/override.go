@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// tableOverride 返回对表生效的单表设置
+// 通配符配置按键名顺序先合并，与表名完全相同的配置最后合并，后合并的设置优先
+func tableOverride(tableName string, cfg config.GeneratorConfig) config.TableOverride {
+	var patterns []string
+	for pattern := range cfg.Tables {
+		if pattern == tableName {
+			continue
+		}
+		if ok, _ := path.Match(pattern, tableName); ok {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Strings(patterns)
+	if _, ok := cfg.Tables[tableName]; ok {
+		patterns = append(patterns, tableName)
+	}
+
+	var merged config.TableOverride
+	for _, pattern := range patterns {
+		o := cfg.Tables[pattern]
+		if o.StructName != "" {
+			merged.StructName = o.StructName
+		}
+		if o.OutputFile != "" {
+			merged.OutputFile = o.OutputFile
+		}
+		merged.Ignore = append(merged.Ignore, o.Ignore...)
+		merged.Fields = mergeMap(merged.Fields, o.Fields)
+		merged.Types = mergeMap(merged.Types, o.Types)
+		merged.Tags = mergeMap(merged.Tags, o.Tags)
+		for _, embed := range o.Embed {
+			if !containsString(merged.Embed, embed) {
+				merged.Embed = append(merged.Embed, embed)
+			}
+		}
+	}
+	return merged
+}
+
+// mergeMap 将src中的键值合并到dst，返回合并后的映射
+func mergeMap(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// FilterColumns 去掉单表设置中忽略的列
+func FilterColumns(tableName string, columns []db.ColumnInfo, cfg config.GeneratorConfig) []db.ColumnInfo {
+	ignore := tableOverride(tableName, cfg).Ignore
+	if len(ignore) == 0 {
+		return columns
+	}
+
+	var kept []db.ColumnInfo
+	for _, col := range columns {
+		ignored := false
+		for _, pattern := range ignore {
+			if ok, _ := path.Match(pattern, col.Name); ok {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			kept = append(kept, col)
+		}
+	}
+	return kept
+}
+
+// TableFieldName 返回表中的列在结构体中的字段名，优先使用单表设置中的字段名
+func TableFieldName(tableName, columnName string, cfg config.GeneratorConfig) string {
+	if name := tableOverride(tableName, cfg).Fields[columnName]; name != "" {
+		return name
+	}
+	return FieldName(columnName)
+}
+
+// embedFields 返回单表设置中嵌入的类型及需要导入的包
+func embedFields(o config.TableOverride) ([]string, []string) {
+	var embeds, imports []string
+	for _, qualified := range o.Embed {
+		goType, importPath := resolveType(strings.TrimSpace(qualified))
+		embeds = append(embeds, goType)
+		if importPath != "" {
+			imports = append(imports, importPath)
+		}
+	}
+	return embeds, imports
+}
+
+// enumColumns 返回需要生成枚举类型的列，单表设置中指定了类型的列不生成
+func enumColumns(columns []db.ColumnInfo, o config.TableOverride) []db.ColumnInfo {
+	var result []db.ColumnInfo
+	for _, col := range columns {
+		if o.Types[col.Name] == "" {
+			result = append(result, col)
+		}
+	}
+	return result
+}
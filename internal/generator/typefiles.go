@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// 单独输出的类型文件模板
+const typeFileTemplate = GeneratedHeader + `
+package {{.PackageName}}
+{{if .Imports}}
+import (
+	{{range .Imports}}{{.}}
+	{{end}}
+)
+{{end}}{{.Code}}`
+
+// GenerateTypeFiles 生成表中使用的共用类型，返回文件名到内容的映射
+// 包括PostgreSQL枚举类型和自定义JSON类型的 Scan/Value 方法，
+// 这些类型可能被多张表共用，因此输出到与结构体文件同目录的单独文件中
+func GenerateTypeFiles(ctx context.Context, database *db.Database, tableName string, cfg config.GeneratorConfig) (map[string]string, error) {
+	columns, err := database.GetTableInfo(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderTypeFiles(database, tableName, columns, cfg)
+}
+
+// renderTypeFiles 根据已读取的列信息渲染共用类型文件
+func renderTypeFiles(database *db.Database, tableName string, columns []db.ColumnInfo, cfg config.GeneratorConfig) (map[string]string, error) {
+	override := tableOverride(tableName, cfg)
+	columns = FilterColumns(tableName, columns, cfg)
+	packageName, structName := TableNaming(database, tableName, cfg)
+	tmpl, err := template.New("typeFile").Parse(typeFileTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{}
+	render := func(fileName, code string, imports []string) error {
+		sort.Strings(imports)
+
+		var buf bytes.Buffer
+		err := tmpl.Execute(&buf, struct {
+			PackageName string
+			Imports     []string
+			Code        string
+		}{packageName, imports, code})
+		if err != nil {
+			return err
+		}
+		files[fileName] = buf.String()
+		return nil
+	}
+
+	for _, enum := range tableEnums(tableName, structName, enumColumns(columns, override), cfg) {
+		if !enum.Shared {
+			continue
+		}
+
+		code, imports, err := renderEnums([]EnumData{enum}, cfg)
+		if err != nil {
+			return nil, err
+		}
+		err = render(enum.FileName, code, imports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, col := range columns {
+		typeName := JSONStructType(tableName, col, cfg)
+		if typeName == "" {
+			continue
+		}
+
+		code, imports, err := renderJSONScanner(typeName)
+		if err != nil {
+			return nil, err
+		}
+		err = render(jsonScannerFileName(typeName), code, imports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// WriteTypeFiles 将表中使用的共用类型写入指定目录
+func WriteTypeFiles(ctx context.Context, database *db.Database, tableName, dir string, cfg config.GeneratorConfig, force bool) error {
+	files, err := GenerateTypeFiles(ctx, database, tableName, cfg)
+	if err != nil {
+		return err
+	}
+
+	paths := make(map[string]string, len(files))
+	for name, content := range files {
+		paths[filepath.Join(dir, name)] = content
+	}
+	_, err = WriteFiles(paths, force)
+	return err
+}
@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"path"
+	"strings"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// 默认的类型配置
+const (
+	defaultUUIDType    = "string"
+	defaultJSONType    = "json.RawMessage"
+	defaultDecimalType = "float64"
+)
+
+// knownPackages 常用包名到导入路径的映射，配置中可直接写 包名.类型
+var knownPackages = map[string]string{
+	"time":      "time",
+	"json":      "encoding/json",
+	"sql":       "database/sql",
+	"pq":        "github.com/lib/pq",
+	"uuid":      "github.com/google/uuid",
+	"datatypes": "gorm.io/datatypes",
+	"gorm":      "gorm.io/gorm",
+	"decimal":   "github.com/shopspring/decimal",
+}
+
+// sliceTypes 本身可以表示NULL、不需要使用指针的类型
+var sliceTypes = map[string]bool{
+	"interface{}":     true,
+	"json.RawMessage": true,
+	"datatypes.JSON":  true,
+	"pq.StringArray":  true,
+	"pq.Int64Array":   true,
+	"pq.Float64Array": true,
+	"pq.BoolArray":    true,
+	"pq.ByteaArray":   true,
+}
+
+// GoType 返回列对应的Go类型及需要导入的包
+func GoType(col db.ColumnInfo, cfg config.GeneratorConfig) (string, []string) {
+	goType, importPath := resolveType(baseGoType(col, cfg))
+
+	var imports []string
+	if importPath != "" {
+		imports = append(imports, importPath)
+	}
+
+	// 处理可空类型
+	if col.IsNullable && !strings.HasPrefix(goType, "[]") && !sliceTypes[goType] {
+		goType = "*" + goType
+	}
+
+	return goType, imports
+}
+
+// FieldType 返回表中的列在结构体中的字段类型及需要导入的包
+// 单表设置中指定的类型优先，枚举列使用生成的命名类型，JSON列优先使用按 表名.列名 配置的类型
+func FieldType(tableName, structName string, col db.ColumnInfo, cfg config.GeneratorConfig) (string, []string) {
+	switch {
+	case tableOverride(tableName, cfg).Types[col.Name] != "":
+		return configuredType(tableOverride(tableName, cfg).Types[col.Name], col)
+	case isSoftDeleteColumn(col, cfg):
+		// gorm.DeletedAt 本身可以表示NULL，不需要指针
+		return "gorm.DeletedAt", []string{"gorm.io/gorm"}
+	case isEnumColumn(col):
+		typeName := EnumTypeName(tableName, structName, col, cfg)
+		if col.IsNullable {
+			typeName = "*" + typeName
+		}
+		return typeName, nil
+	case isJSONColumn(col) && cfg.JSONTypes[tableName+"."+col.Name] != "":
+		return configuredType(cfg.JSONTypes[tableName+"."+col.Name], col)
+	}
+
+	return GoType(col, cfg)
+}
+
+// configuredType 返回配置中为列指定的类型及需要导入的包，可空列使用指针
+func configuredType(qualified string, col db.ColumnInfo) (string, []string) {
+	goType, importPath := resolveType(qualified)
+
+	var imports []string
+	if importPath != "" {
+		imports = append(imports, importPath)
+	}
+	if col.IsNullable && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "*") && !sliceTypes[goType] {
+		goType = "*" + goType
+	}
+	return goType, imports
+}
+
+// resolveType 将配置中的类型名解析为代码中使用的类型名和导入路径
+// 类型名可以是内置类型、常用包中的类型(如 json.RawMessage)，
+// 也可以带完整导入路径(如 github.com/google/uuid.UUID)
+func resolveType(qualified string) (goType, importPath string) {
+	i := strings.LastIndex(qualified, ".")
+	if i < 0 || strings.HasPrefix(qualified, "[]") || strings.HasPrefix(qualified, "map[") {
+		return qualified, ""
+	}
+
+	pkg, name := qualified[:i], qualified[i+1:]
+	if strings.Contains(pkg, "/") {
+		return path.Base(pkg) + "." + name, pkg
+	}
+	if p, ok := knownPackages[pkg]; ok {
+		return qualified, p
+	}
+	return qualified, ""
+}
+
+// baseGoType 将SQL类型映射到不含指针的Go类型
+func baseGoType(col db.ColumnInfo, cfg config.GeneratorConfig) string {
+	sqlType := col.Type
+	// 域类型按其基础类型映射
+	if col.BaseType != "" {
+		sqlType = col.BaseType
+	}
+	sqlType = db.TypeName(sqlType)
+
+	switch {
+	case col.IsArray || strings.HasSuffix(sqlType, "[]"):
+		return arrayGoType(col, cfg)
+	case len(col.EnumValues) > 0:
+		return "string"
+	case sqlType == "uuid" || sqlType == "uniqueidentifier":
+		if cfg.UUIDType != "" {
+			return cfg.UUIDType
+		}
+		return defaultUUIDType
+	case isJSONColumn(col):
+		if cfg.JSONType != "" {
+			return cfg.JSONType
+		}
+		return defaultJSONType
+	case col.GoType != "":
+		return col.GoType
+	}
+
+	return mapSQLTypeToGoType(sqlType, cfg)
+}
+
+// isJSONColumn 判断列是否为json/jsonb类型
+func isJSONColumn(col db.ColumnInfo) bool {
+	sqlType := col.Type
+	if col.BaseType != "" {
+		sqlType = col.BaseType
+	}
+	switch db.TypeName(sqlType) {
+	case "json", "jsonb":
+		return !col.IsArray
+	}
+	return false
+}
+
+// arrayGoType 将PostgreSQL数组映射到lib/pq的数组类型
+func arrayGoType(col db.ColumnInfo, cfg config.GeneratorConfig) string {
+	elem := strings.ToLower(col.ElemType)
+	if elem == "" {
+		elem = strings.TrimSuffix(strings.ToLower(col.Type), "[]")
+	}
+
+	switch mapSQLTypeToGoType(elem, cfg) {
+	case "int64":
+		return "pq.Int64Array"
+	case "float64":
+		return "pq.Float64Array"
+	case "bool":
+		return "pq.BoolArray"
+	case "[]byte":
+		return "pq.ByteaArray"
+	default:
+		// 文本、枚举、uuid等其他元素类型按字符串数组处理
+		return "pq.StringArray"
+	}
+}
+
+// mapSQLTypeToGoType 将SQL类型映射到Go类型，按完整的类型名匹配，不认识的类型映射为 interface{}
+// decimal/numeric等定点数使用配置的 decimal_type
+func mapSQLTypeToGoType(sqlType string, cfg config.GeneratorConfig) string {
+	kind, ok := db.TypeKind(sqlType)
+	if !ok {
+		return "interface{}"
+	}
+
+	switch kind {
+	case db.KindInt:
+		return "int64"
+	case db.KindFloat:
+		return "float64"
+	case db.KindDecimal:
+		if cfg.DecimalType != "" {
+			return cfg.DecimalType
+		}
+		return defaultDecimalType
+	case db.KindBool:
+		return "bool"
+	case db.KindTime:
+		return "time.Time"
+	case db.KindBytes:
+		return "[]byte"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+func TestGoType(t *testing.T) {
+	decimal := config.GeneratorConfig{DecimalType: "github.com/shopspring/decimal.Decimal"}
+	uuid := config.GeneratorConfig{UUIDType: "github.com/google/uuid.UUID"}
+
+	tests := []struct {
+		name        string
+		col         db.ColumnInfo
+		cfg         config.GeneratorConfig
+		want        string
+		wantImports []string
+	}{
+		{name: "整数", col: db.ColumnInfo{Type: "int(11) unsigned"}, want: "int64"},
+		{name: "可空整数", col: db.ColumnInfo{Type: "integer", IsNullable: true}, want: "*int64"},
+		{name: "浮点数", col: db.ColumnInfo{Type: "double precision"}, want: "float64"},
+		{name: "定点数默认为float64", col: db.ColumnInfo{Type: "numeric(10,2)"}, want: "float64"},
+		{name: "定点数使用字符串", col: db.ColumnInfo{Type: "numeric(10,2)"}, cfg: config.GeneratorConfig{DecimalType: "string"}, want: "string"},
+		{name: "配置的定点数类型", col: db.ColumnInfo{Type: "decimal(10,2)"}, cfg: decimal, want: "decimal.Decimal", wantImports: []string{"github.com/shopspring/decimal"}},
+		{name: "可空的定点数", col: db.ColumnInfo{Type: "money", IsNullable: true}, cfg: decimal, want: "*decimal.Decimal", wantImports: []string{"github.com/shopspring/decimal"}},
+		{name: "几何类型", col: db.ColumnInfo{Type: "point"}, want: "interface{}"},
+		{name: "名称含int的类型", col: db.ColumnInfo{Type: "interval"}, want: "string"},
+		{name: "带时区的时间", col: db.ColumnInfo{Type: "time with time zone"}, want: "time.Time", wantImports: []string{"time"}},
+		{name: "位串", col: db.ColumnInfo{Type: "bit varying(8)"}, want: "string"},
+		{name: "时间", col: db.ColumnInfo{Type: "timestamp(3) without time zone"}, want: "time.Time", wantImports: []string{"time"}},
+		{name: "二进制", col: db.ColumnInfo{Type: "bytea"}, want: "[]byte"},
+		{name: "uuid", col: db.ColumnInfo{Type: "uuid"}, cfg: uuid, want: "uuid.UUID", wantImports: []string{"github.com/google/uuid"}},
+		{name: "整数数组", col: db.ColumnInfo{Type: "integer[]", IsArray: true, ElemType: "integer"}, want: "pq.Int64Array", wantImports: []string{"github.com/lib/pq"}},
+		{name: "时间戳数组", col: db.ColumnInfo{Type: "timestamp[]", IsArray: true, ElemType: "timestamp"}, want: "pq.StringArray", wantImports: []string{"github.com/lib/pq"}},
+		{name: "json", col: db.ColumnInfo{Type: "jsonb"}, want: "json.RawMessage", wantImports: []string{"encoding/json"}},
+		{name: "域按基础类型", col: db.ColumnInfo{Type: "positive_int", BaseType: "integer"}, want: "int64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, imports := GoType(tt.col, tt.cfg)
+			if got != tt.want || !reflect.DeepEqual(imports, tt.wantImports) {
+				t.Errorf("GoType() = %s %v，期望 %s %v", got, imports, tt.want, tt.wantImports)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GeneratedHeader 生成的Go文件的首行，覆盖已有文件前据此判断文件是否由 trade2sql 生成
+const GeneratedHeader = "// 代码由 trade2sql 自动生成"
+
+// 用户区域的开始和结束标记，标记后为区域名
+// 重新生成时保留已有文件中用户区域的内容
+const (
+	regionBegin = "// trade2sql:user-begin "
+	regionEnd   = "// trade2sql:user-end "
+)
+
+// ExtSuffix 手写代码文件的后缀，如 user_model.go 对应 user_model_ext.go，生成器不会写入这类文件
+const ExtSuffix = "_ext.go"
+
+// IsGenerated 判断文件内容是否由 trade2sql 生成
+func IsGenerated(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(GeneratedHeader))
+}
+
+// checkOverwrite 检查是否可以写入文件，已有的文件不是 trade2sql 生成的且未指定force时返回错误
+func checkOverwrite(path string, force bool) error {
+	if strings.HasSuffix(path, ExtSuffix) {
+		return fmt.Errorf("%s 是手写代码文件，不能写入生成的代码", path)
+	}
+
+	old, err := os.ReadFile(path)
+	if os.IsNotExist(err) || force {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !IsGenerated(old) {
+		return fmt.Errorf("%s 不是 trade2sql 生成的文件，使用 -force 覆盖", path)
+	}
+	return nil
+}
+
+// WriteFiles 写入生成的文件，files为文件路径到生成内容的映射，返回按路径排序的文件路径
+// 保留已有文件中用户区域的内容，已有的文件不是 trade2sql 生成的时拒绝覆盖，force为true时仍然覆盖
+// 先检查全部文件并合并用户区域，有文件不能写入时不写入任何文件
+func WriteFiles(files map[string]string, force bool) ([]string, error) {
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	merged := make(map[string]string, len(files))
+	for _, path := range paths {
+		err := checkOverwrite(path, force)
+		if err != nil {
+			return nil, err
+		}
+
+		content := files[path]
+		old, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			content, err = mergeRegions(content, string(old))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+		}
+		merged[path] = content
+	}
+
+	for _, path := range paths {
+		err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if err != nil {
+			return nil, err
+		}
+		err = os.WriteFile(path, []byte(merged[path]), 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// region 用户区域
+type region struct {
+	name  string
+	lines []string
+}
+
+// parseRegions 按出现顺序返回内容中的用户区域
+func parseRegions(content string) ([]region, error) {
+	var regions []region
+	var current *region
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, regionBegin):
+			if current != nil {
+				return nil, fmt.Errorf("用户区域 %s 没有结束标记", current.name)
+			}
+			current = &region{name: strings.TrimSpace(strings.TrimPrefix(trimmed, regionBegin))}
+		case strings.HasPrefix(trimmed, regionEnd):
+			name := strings.TrimSpace(strings.TrimPrefix(trimmed, regionEnd))
+			if current == nil || current.name != name {
+				return nil, fmt.Errorf("用户区域 %s 的结束标记没有对应的开始标记", name)
+			}
+			regions = append(regions, *current)
+			current = nil
+		case current != nil:
+			current.lines = append(current.lines, line)
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("用户区域 %s 没有结束标记", current.name)
+	}
+	return regions, nil
+}
+
+// mergeRegions 用已有文件中用户区域的内容替换新生成内容中的同名区域
+// 新生成的内容中已没有的区域无处安放，如结构体改名后的 <结构体>.fields，返回错误以免丢失手写的代码
+func mergeRegions(generated, existing string) (string, error) {
+	regions, err := parseRegions(existing)
+	if err != nil {
+		return "", err
+	}
+	if len(regions) == 0 {
+		return generated, nil
+	}
+
+	kept := map[string][]string{}
+	for _, r := range regions {
+		kept[r.name] = r.lines
+	}
+
+	var out []string
+	used := map[string]bool{}
+	skipping := false
+	for _, line := range strings.Split(generated, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, regionBegin):
+			out = append(out, line)
+			name := strings.TrimSpace(strings.TrimPrefix(trimmed, regionBegin))
+			if lines, ok := kept[name]; ok {
+				out = append(out, lines...)
+				used[name] = true
+				skipping = true
+			}
+		case strings.HasPrefix(trimmed, regionEnd):
+			out = append(out, line)
+			skipping = false
+		case !skipping:
+			out = append(out, line)
+		}
+	}
+
+	for _, r := range regions {
+		if !used[r.name] && !blankLines(r.lines) {
+			return "", fmt.Errorf("用户区域 %s 在新生成的代码中已没有对应的位置，请将其中的代码移到 %s 文件后删除该区域", r.name, ExtSuffix)
+		}
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// blankLines 判断各行是否都是空行
+func blankLines(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ChangeStatus 生成的文件相对已有文件的变化
+type ChangeStatus int
+
+const (
+	Unchanged ChangeStatus = iota // 内容相同
+	Created                       // 文件不存在，将新建
+	Modified                      // 内容不同，将覆盖
+)
+
+// String 返回变化的中文说明
+func (s ChangeStatus) String() string {
+	switch s {
+	case Created:
+		return "新建"
+	case Modified:
+		return "修改"
+	default:
+		return "未变"
+	}
+}
+
+// Change 生成的文件与已有文件的比较结果
+type Change struct {
+	Path      string
+	Status    ChangeStatus
+	Old       string // 已有文件的内容，新建时为空
+	New       string // 保留用户区域后将写入的内容
+	Protected bool   // 已有的文件不是 trade2sql 生成的，不指定force时不会覆盖
+}
+
+// Plan 比较将要写入的文件和已有的文件，不写入任何文件，files为文件路径到生成内容的映射
+// 返回的结果按文件路径排序
+func Plan(files map[string]string) ([]Change, error) {
+	var changes []Change
+	for path, content := range files {
+		change := Change{Path: path, New: content}
+
+		old, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			change.Status = Created
+		case err != nil:
+			return nil, err
+		default:
+			change.Old = string(old)
+			change.Protected = !IsGenerated(old)
+			change.New, err = mergeRegions(content, change.Old)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			if change.New != change.Old {
+				change.Status = Modified
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeRegions(t *testing.T) {
+	generated := strings.Join([]string{
+		GeneratedHeader,
+		"package model",
+		"",
+		"type User struct {",
+		"	ID int64",
+		"	// trade2sql:user-begin User.fields",
+		"	// trade2sql:user-end User.fields",
+		"}",
+		"",
+		"// trade2sql:user-begin User.methods",
+		"// trade2sql:user-end User.methods",
+		"",
+	}, "\n")
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "没有用户区域",
+			existing: GeneratedHeader + "\npackage model\n",
+			want:     generated,
+		},
+		{
+			name: "保留区域内容",
+			existing: strings.Join([]string{
+				GeneratedHeader,
+				"type User struct {",
+				"	Name string",
+				"	// trade2sql:user-begin User.fields",
+				"	Extra string `json:\"extra\"`",
+				"	// trade2sql:user-end User.fields",
+				"}",
+				"// trade2sql:user-begin User.methods",
+				"func (u User) Hello() {}",
+				"// trade2sql:user-end User.methods",
+			}, "\n"),
+			want: strings.Join([]string{
+				GeneratedHeader,
+				"package model",
+				"",
+				"type User struct {",
+				"	ID int64",
+				"	// trade2sql:user-begin User.fields",
+				"	Extra string `json:\"extra\"`",
+				"	// trade2sql:user-end User.fields",
+				"}",
+				"",
+				"// trade2sql:user-begin User.methods",
+				"func (u User) Hello() {}",
+				"// trade2sql:user-end User.methods",
+				"",
+			}, "\n"),
+		},
+		{
+			name: "空的孤立区域丢弃",
+			existing: strings.Join([]string{
+				"// trade2sql:user-begin Account.fields",
+				"",
+				"// trade2sql:user-end Account.fields",
+			}, "\n"),
+			want: generated,
+		},
+		{
+			name: "孤立区域报错",
+			existing: strings.Join([]string{
+				"// trade2sql:user-begin Account.fields",
+				"	Extra string",
+				"// trade2sql:user-end Account.fields",
+			}, "\n"),
+			wantErr: "用户区域 Account.fields",
+		},
+		{
+			name:     "缺少结束标记",
+			existing: "// trade2sql:user-begin User.fields\n",
+			wantErr:  "没有结束标记",
+		},
+		{
+			name:     "结束标记不匹配",
+			existing: "// trade2sql:user-begin User.fields\n// trade2sql:user-end User.methods\n",
+			wantErr:  "没有对应的开始标记",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeRegions(generated, tt.existing)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("错误 = %v，期望包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("结果 =\n%s\n期望\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFilesChecksAllTargets(t *testing.T) {
+	dir := t.TempDir()
+	handWritten := filepath.Join(dir, "b_model.go")
+	err := os.WriteFile(handWritten, []byte("package model\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(dir, "a_model.go"): GeneratedHeader + "\npackage model\n",
+		handWritten:                      GeneratedHeader + "\npackage model\n",
+	}
+
+	_, err = WriteFiles(files, false)
+	if err == nil {
+		t.Fatal("期望拒绝覆盖手写的文件")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a_model.go")); !os.IsNotExist(err) {
+		t.Errorf("检查失败时不应写入任何文件")
+	}
+
+	paths, err := WriteFiles(files, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || paths[0] != filepath.Join(dir, "a_model.go") {
+		t.Errorf("写入的文件 = %v", paths)
+	}
+}
@@ -0,0 +1,193 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/trade2sql/internal/config"
+	"github.com/trade2sql/internal/db"
+)
+
+// connForm 数据库连接表单，按所选数据库类型只显示该类型使用的连接参数
+type connForm struct {
+	typeSelect *widget.Select
+	host       *widget.Entry
+	port       *widget.Entry
+	user       *widget.Entry
+	password   *widget.Entry
+	database   *widget.Entry
+	sslMode    *widget.SelectEntry
+	socket     *widget.Entry
+	file       *widget.Entry
+	params     *widget.Entry
+	conn       *widget.Entry
+	schema     *widget.Entry
+
+	rows    map[string][]fyne.CanvasObject // 连接参数字段名到该行的标签和输入框
+	content *fyne.Container
+}
+
+// newConnForm 根据配置创建连接表单
+func newConnForm(cfg config.DatabaseConfig) *connForm {
+	f := &connForm{
+		host:     widget.NewEntry(),
+		port:     widget.NewEntry(),
+		user:     widget.NewEntry(),
+		password: widget.NewPasswordEntry(),
+		database: widget.NewEntry(),
+		sslMode:  widget.NewSelectEntry([]string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}),
+		socket:   widget.NewEntry(),
+		file:     widget.NewEntry(),
+		params:   widget.NewEntry(),
+		conn:     widget.NewEntry(),
+		schema:   widget.NewEntry(),
+		rows:     map[string][]fyne.CanvasObject{},
+	}
+
+	f.host.SetPlaceHolder("localhost")
+	f.password.SetPlaceHolder("可写作 env:变量名 或 file:文件路径，避免保存明文密码")
+	f.port.SetPlaceHolder("留空使用默认端口")
+	f.socket.SetPlaceHolder("例如: /var/run/mysqld/mysqld.sock，设置后忽略主机和端口")
+	f.file.SetPlaceHolder("SQLite数据库文件路径")
+	f.params.SetPlaceHolder("例如: charset=utf8mb4&parseTime=true")
+	f.conn.SetPlaceHolder("可选，填写后覆盖以上连接参数")
+	f.schema.SetPlaceHolder("留空列出全部模式")
+
+	f.content = container.New(layout.NewFormLayout())
+	f.typeSelect = widget.NewSelect(db.DialectNames(), f.showFields)
+	f.addRow("", "数据库类型", f.typeSelect)
+	f.addRow(db.FieldHost, "主机", f.host)
+	f.addRow(db.FieldPort, "端口", f.port)
+	f.addRow(db.FieldSocket, "套接字", f.socket)
+	f.addRow(db.FieldUser, "用户名", f.user)
+	f.addRow(db.FieldPassword, "密码", f.password)
+	f.addRow(db.FieldDatabase, "数据库", f.database)
+	f.addRow(db.FieldFile, "数据库文件", f.file)
+	f.addRow(db.FieldSSLMode, "SSL模式", f.sslMode)
+	f.addRow(db.FieldParams, "附加参数", f.params)
+	f.addRow("schema", "模式", f.schema)
+	f.addRow("", "连接字符串", f.conn)
+
+	f.set(cfg)
+	return f
+}
+
+// set 用配置填充表单
+func (f *connForm) set(cfg config.DatabaseConfig) {
+	f.host.SetText(cfg.Host)
+	f.port.SetText("")
+	if cfg.Port != 0 {
+		f.port.SetText(strconv.Itoa(cfg.Port))
+	}
+	f.user.SetText(cfg.User)
+	f.password.SetText(cfg.Password)
+	f.database.SetText(cfg.Database)
+	f.sslMode.SetText(cfg.SSLMode)
+	f.socket.SetText(cfg.Socket)
+	f.file.SetText(cfg.File)
+	f.params.SetText(formatParams(cfg.Params))
+	f.conn.SetText(cfg.Connection)
+	f.schema.SetText(cfg.Schema)
+
+	if dialect, err := db.LookupDialect(cfg.Type); err == nil {
+		f.typeSelect.SetSelected(dialect.Name())
+	} else {
+		f.typeSelect.ClearSelected()
+		f.showFields("")
+	}
+}
+
+// addRow 向表单添加一行，field为空的行始终显示
+func (f *connForm) addRow(field, label string, input fyne.CanvasObject) {
+	row := []fyne.CanvasObject{widget.NewLabel(label), input}
+	f.content.Add(row[0])
+	f.content.Add(row[1])
+	if field != "" {
+		f.rows[field] = row
+	}
+}
+
+// showFields 只显示所选数据库类型使用的连接参数
+func (f *connForm) showFields(dbType string) {
+	visible := map[string]bool{}
+	if dialect, err := db.LookupDialect(dbType); err == nil {
+		for _, field := range dialect.ConnFields() {
+			visible[field] = true
+		}
+		visible["schema"] = dialect.SupportsSchema()
+	}
+
+	for field, row := range f.rows {
+		for _, obj := range row {
+			if visible[field] {
+				obj.Show()
+			} else {
+				obj.Hide()
+			}
+		}
+	}
+	f.content.Refresh()
+}
+
+// config 用表单内容覆盖base中的连接配置，超时等表单中没有的设置保持不变
+func (f *connForm) config(base config.DatabaseConfig) (config.DatabaseConfig, error) {
+	cfg := base
+	cfg.Type = f.typeSelect.Selected
+	cfg.Host = strings.TrimSpace(f.host.Text)
+	cfg.User = f.user.Text
+	cfg.Password = f.password.Text
+	cfg.Database = strings.TrimSpace(f.database.Text)
+	cfg.SSLMode = strings.TrimSpace(f.sslMode.Text)
+	cfg.Socket = strings.TrimSpace(f.socket.Text)
+	cfg.File = strings.TrimSpace(f.file.Text)
+	cfg.Params = parseParams(f.params.Text)
+	cfg.Connection = strings.TrimSpace(f.conn.Text)
+	cfg.Schema = strings.TrimSpace(f.schema.Text)
+
+	cfg.Port = 0
+	if port := strings.TrimSpace(f.port.Text); port != "" {
+		n, err := strconv.Atoi(port)
+		if err != nil {
+			return cfg, fmt.Errorf("端口必须是数字: %s", port)
+		}
+		cfg.Port = n
+	}
+
+	if cfg.Type == "" {
+		return cfg, fmt.Errorf("请选择数据库类型")
+	}
+	return cfg, nil
+}
+
+// parseParams 解析 key=value&key2=value2 形式的附加参数
+func parseParams(s string) map[string]string {
+	var params map[string]string
+	for _, pair := range strings.Split(s, "&") {
+		k, v, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		if k == "" {
+			continue
+		}
+		if params == nil {
+			params = map[string]string{}
+		}
+		params[k] = v
+	}
+	return params
+}
+
+// formatParams 将附加参数格式化为 key=value&key2=value2，键按字母排序
+func formatParams(params map[string]string) string {
+	var pairs []string
+	for k, v := range params {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
@@ -1,10 +1,12 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -41,27 +43,32 @@ import (
 // 	os.Setenv("FYNE_FONT_MONOSPACE", fontPath)
 // }
 
-// StartGUI 启动GUI界面
-func StartGUI() {
+// StartGUI 启动GUI界面，configPath为空时按默认顺序查找配置文件
+func StartGUI(configPath string) {
 	a := app.New()
 	a.SetIcon(resources.AppIcon)
 	w := a.NewWindow("Trade2SQL - 数据库表结构生成工具")
 	w.Resize(fyne.NewSize(1000, 700)) // 增加窗口大小以适应结构体预览
 
-	// 加载配置
-	cfg, err := config.Load("config.yaml")
-	if err != nil {
+	// 加载配置，没有配置文件时保存到当前目录的 trade2sql.yaml
+	// 配置文件加载失败时使用默认配置且不保存，以免覆盖用户的配置文件
+	cfg, path, loadErr := config.Discover(configPath)
+	switch {
+	case loadErr != nil:
 		cfg = config.Default()
+		path = ""
+	case path == "":
+		path = config.FileName
 	}
 
-	// 数据库类型选择
-	dbTypeSelect := widget.NewSelect([]string{"mysql", "postgres", "sqlite3"}, nil)
-	dbTypeSelect.SetSelected(cfg.Database.Type)
+	// 连接选择，可以选择已有的连接或输入新名称，生成成功后设置保存到该连接
+	profileSelect := widget.NewSelectEntry(cfg.ProfileNames())
+	profileSelect.SetText(cfg.DefaultProfile)
+	profileSelect.SetPlaceHolder("留空使用顶层数据库配置，输入新名称保存为新连接")
+	dbCfg, genCfg := profileSettings(cfg, cfg.DefaultProfile)
 
-	// 数据库连接字符串输入
-	dbConnEntry := widget.NewEntry()
-	dbConnEntry.SetText(cfg.Database.Connection)
-	dbConnEntry.SetPlaceHolder("例如: user:password@tcp(localhost:3306)/database")
+	// 数据库连接表单
+	connForm := newConnForm(dbCfg)
 
 	// 表列表选择
 	tableList := widget.NewList(
@@ -81,57 +88,82 @@ func StartGUI() {
 
 	// 输出包名
 	packageNameEntry := widget.NewEntry()
-	packageNameEntry.SetText(cfg.Generator.PackageName)
+	packageNameEntry.SetText(genCfg.PackageName)
 	packageNameEntry.SetPlaceHolder("输出的包名")
 
 	// 标签格式
 	tagFormatEntry := widget.NewEntry()
-	tagFormatEntry.SetText(cfg.Generator.TagFormat)
+	tagFormatEntry.SetText(genCfg.TagFormat)
 	tagFormatEntry.SetPlaceHolder("标签格式，如: json,db")
 
+	// 选择已有连接时载入其设置
+	profileSelect.OnChanged = func(name string) {
+		if _, ok := cfg.Profiles[name]; !ok {
+			return
+		}
+		dbCfg, genCfg := profileSettings(cfg, name)
+		connForm.set(dbCfg)
+		packageNameEntry.SetText(genCfg.PackageName)
+		tagFormatEntry.SetText(genCfg.TagFormat)
+	}
+
 	// 输出路径
 	outputPathEntry := widget.NewEntry()
 	str, _ := os.Getwd()
 	outputPathEntry.SetText(str)
 	outputPathEntry.SetPlaceHolder("输出文件路径")
 
+	// 是否覆盖不是 trade2sql 生成的已有文件
+	forceCheck := widget.NewCheck("覆盖非生成的文件", nil)
+
 	// 连接并获取表列表按钮
 	connectBtn := widget.NewButton("连接数据库并获取表列表", func() {
-		dbType := dbTypeSelect.Selected
-		dbConn := dbConnEntry.Text
-
-		database, err := db.Connect(dbType, dbConn)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("连接失败: %v", err), w)
-			return
-		}
-		defer database.Close()
-
-		// 获取表列表
-		tables, err := database.GetTableList()
+		base, _ := profileSettings(cfg, strings.TrimSpace(profileSelect.Text))
+		dbCfg, err := connForm.config(base)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("获取表列表失败: %v", err), w)
+			dialog.ShowError(err, w)
 			return
 		}
 
-		// 排序表名
-		sort.Strings(tables)
-
-		// 更新表列表
-		tableList.Length = func() int { return len(tables) }
-		tableList.CreateItem = func() fyne.CanvasObject { return widget.NewLabel("") }
-		tableList.UpdateItem = func(id widget.ListItemID, obj fyne.CanvasObject) {
-			obj.(*widget.Label).SetText(tables[id])
-		}
-		tableList.OnSelected = func(id widget.ListItemID) {
-			selectedTable = tables[id]
-			// if outputPathEntry.Text == "" {
-			// 	outputPathEntry.SetText(fmt.Sprintf("%s_model.go", selectedTable))
-			// 	// outputPathEntry.Disable()
-			// }
-		}
-		tableList.Refresh()
-		// dialog.ShowInformation("连接成功", fmt.Sprintf("成功获取到 %d 个表", len(tables)), w)
+		var tables []db.TableInfo
+		runTask(w, "正在连接数据库...", func(ctx context.Context) error {
+			database, err := dbCfg.Connect(ctx)
+			if err != nil {
+				return fmt.Errorf("连接失败: %v", err)
+			}
+			defer database.Close()
+
+			// 获取表列表
+			tables, err = database.GetTables(ctx)
+			if err != nil {
+				return fmt.Errorf("获取表列表失败: %v", err)
+			}
+			return nil
+		}, func(err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+
+			// 排序表名
+			sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+
+			// 更新表列表
+			tableList.Length = func() int { return len(tables) }
+			tableList.CreateItem = func() fyne.CanvasObject { return widget.NewLabel("") }
+			tableList.UpdateItem = func(id widget.ListItemID, obj fyne.CanvasObject) {
+				obj.(*widget.Label).SetText(tableLabel(tables[id]))
+			}
+			tableList.OnSelected = func(id widget.ListItemID) {
+				selectedTable = tables[id].QualifiedName()
+				// if outputPathEntry.Text == "" {
+				// 	outputPathEntry.SetText(fmt.Sprintf("%s_model.go", selectedTable))
+				// 	// outputPathEntry.Disable()
+				// }
+			}
+			tableList.Refresh()
+			// dialog.ShowInformation("连接成功", fmt.Sprintf("成功获取到 %d 个表", len(tables)), w)
+		})
 	})
 
 	// 结构体预览区域
@@ -147,8 +179,6 @@ func StartGUI() {
 
 	// 生成按钮
 	generateBtn := widget.NewButton("生成结构体", func() {
-		dbType := dbTypeSelect.Selected
-		dbConn := dbConnEntry.Text
 		packageName := packageNameEntry.Text
 		tagFormat := tagFormatEntry.Text
 		outputPath := outputPathEntry.Text
@@ -163,69 +193,96 @@ func StartGUI() {
 		// 	outputPath = fmt.Sprintf("%s_model.go", selectedTable)
 		// }
 
+		// 生成结构体
+		profileName := strings.TrimSpace(profileSelect.Text)
+		base, genCfg := profileSettings(cfg, profileName)
+		genCfg.PackageName = packageName
+		genCfg.TagFormat = tagFormat
+
 		// 确保输出路径是绝对路径
 		var outPath string
 		if !filepath.IsAbs(outputPath) {
 			outPath, _ = filepath.Abs(outputPath)
-			os.MkdirAll(outPath, os.ModePerm)
-			outputPath = filepath.Join(outPath, fmt.Sprintf("%s_model.go", selectedTable))
 		} else {
 			outPath = outputPath
-			os.MkdirAll(outPath, os.ModePerm)
-			outputPath = filepath.Join(outPath, fmt.Sprintf("%s_model.go", selectedTable))
 		}
 
-		// 连接数据库
-		database, err := db.Connect(dbType, dbConn)
+		dbCfg, err := connForm.config(base)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("连接数据库失败: %v", err), w)
+			dialog.ShowError(err, w)
 			return
 		}
-		defer database.Close()
 
-		// 生成结构体
-		genCfg := config.GeneratorConfig{
-			PackageName: packageName,
-			TagFormat:   tagFormat,
-		}
-
-		// 获取生成的结构体内容
-		structContent, err := generator.GenerateStructContent(database, selectedTable, genCfg)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("生成结构体失败: %v", err), w)
-			return
-		}
-
-		// 显示在预览区域
-		structPreview.SetText(structContent)
-
-		// 保存到文件
-		err = os.WriteFile(outputPath, []byte(structContent), 0644)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("保存文件失败: %v", err), w)
-			return
-		}
-
-		// 保存配置
-		cfg.Database.Type = dbType
-		cfg.Database.Connection = dbConn
-		cfg.Generator.PackageName = packageName
-		cfg.Generator.TagFormat = tagFormat
-		err = config.Save("config.yaml", cfg)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("保存配置失败: %v", err), w)
-		}
-
-		dialog.ShowInformation("生成成功", fmt.Sprintf("已成功生成结构体到目录%v", outPath), w)
+		table := selectedTable
+		force := forceCheck.Checked
+		var structContent string
+		runTask(w, "正在生成结构体...", func(ctx context.Context) error {
+			// 连接数据库
+			database, err := dbCfg.Connect(ctx)
+			if err != nil {
+				return fmt.Errorf("连接数据库失败: %v", err)
+			}
+			defer database.Close()
+
+			filePath := filepath.Join(outPath, generator.OutputFileName(database, table, genCfg))
+
+			// 生成结构体和共用类型，与命令行相同，全部文件检查通过后才写入
+			files, err := generator.StructFiles(ctx, database, table, filePath, genCfg)
+			if err != nil {
+				return fmt.Errorf("生成结构体失败: %v", err)
+			}
+			structContent = files[filePath]
+
+			_, err = generator.WriteFiles(files, force)
+			if err != nil {
+				return fmt.Errorf("保存文件失败: %v", err)
+			}
+			return nil
+		}, func(err error) {
+			// 显示在预览区域
+			if structContent != "" {
+				structPreview.SetText(structContent)
+			}
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+
+			// 保存配置，原来引用环境变量或密钥的密码保持引用
+			save := func(database config.DatabaseConfig) {
+				saveProfile(cfg, profileName, database, packageName, tagFormat)
+				profileSelect.SetOptions(cfg.ProfileNames())
+				if path != "" {
+					err := config.Save(path, cfg)
+					if err != nil {
+						dialog.ShowError(fmt.Errorf("保存配置失败: %v", err), w)
+					}
+				}
+
+				dialog.ShowInformation("生成成功", fmt.Sprintf("已成功生成结构体到目录%v", outPath), w)
+			}
+			database := keepSecretRef(cfg, profileName, dbCfg)
+			if path == "" || !plaintextPassword(cfg, profileName, database) {
+				save(database)
+				return
+			}
+
+			// 写入明文密码前确认，不保存时配置文件中的密码保持不变
+			message := fmt.Sprintf("密码将以明文保存到 %s。\n选择\"否\"不保存密码，可改为填写 env:变量名 或 file:文件路径引用密码。", path)
+			dialog.ShowConfirm("保存明文密码", message, func(ok bool) {
+				if !ok {
+					database.Password = savedPassword(cfg, profileName)
+				}
+				save(database)
+			}, w)
+		})
 	})
 
 	// 左侧面板 - 数据库连接和表列表
 	leftPanel := container.NewVBox(
 		widget.NewLabel("数据库连接"),
-		widget.NewForm(
-			widget.NewFormItem("数据库类型", dbTypeSelect),
-			widget.NewFormItem("连接字符串", dbConnEntry),
-		),
+		widget.NewForm(widget.NewFormItem("连接", profileSelect)),
+		connForm.content,
 		connectBtn,
 		widget.NewSeparator(),
 		tableListContainer,
@@ -239,6 +296,7 @@ func StartGUI() {
 			widget.NewFormItem("标签格式", tagFormatEntry),
 			widget.NewFormItem("输出文件名", outputPathEntry),
 		),
+		forceCheck,
 		generateBtn,
 	)
 
@@ -257,5 +315,23 @@ func StartGUI() {
 	mainContent.SetOffset(0.5) // 上部分占50%
 
 	w.SetContent(mainContent)
+	if loadErr != nil {
+		dialog.ShowError(fmt.Errorf("%v\n使用默认配置，修正配置文件并重新启动前不会保存设置", loadErr), w)
+	}
 	w.ShowAndRun()
 }
+
+// tableLabel 表列表中显示的文本，视图和注释附在表名后
+func tableLabel(t db.TableInfo) string {
+	label := t.QualifiedName()
+	switch t.Type {
+	case db.TableTypeView:
+		label += " [视图]"
+	case db.TableTypeMaterializedView:
+		label += " [物化视图]"
+	}
+	if t.Comment != "" {
+		label += " - " + t.Comment
+	}
+	return label
+}
@@ -0,0 +1,69 @@
+package gui
+
+import (
+	"github.com/trade2sql/internal/config"
+)
+
+// profileSettings 返回连接的数据库配置和生成器配置
+// name为空时使用顶层配置，配置中还没有的新连接使用全局生成器配置
+func profileSettings(cfg *config.Config, name string) (config.DatabaseConfig, config.GeneratorConfig) {
+	if name == "" {
+		return cfg.Database, cfg.Generator
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return config.DatabaseConfig{}, cfg.Generator
+	}
+
+	database, generator, _ := cfg.Select(name)
+	return database, generator
+}
+
+// saveProfile 将界面上的连接和生成器设置保存到指定连接，name为空时保存到顶层配置
+// 连接没有专用的生成器配置且包名、标签格式与全局配置相同时不单独保存
+func saveProfile(cfg *config.Config, name string, database config.DatabaseConfig, packageName, tagFormat string) {
+	if name == "" {
+		cfg.Database = database
+		cfg.Generator.PackageName = packageName
+		cfg.Generator.TagFormat = tagFormat
+		return
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]config.Profile{}
+	}
+	profile := cfg.Profiles[name]
+	profile.Database = database
+	if profile.Generator != nil {
+		profile.Generator.PackageName = packageName
+		profile.Generator.TagFormat = tagFormat
+	} else if packageName != cfg.Generator.PackageName || tagFormat != cfg.Generator.TagFormat {
+		profile.Generator = &config.GeneratorConfig{
+			PackageName: packageName,
+			TagFormat:   tagFormat,
+		}
+	}
+	cfg.Profiles[name] = profile
+}
+
+// savedPassword 返回配置文件中连接已保存的密码，name为空时为顶层配置的密码
+func savedPassword(cfg *config.Config, name string) string {
+	if name == "" {
+		return cfg.Database.Password
+	}
+	return cfg.Profiles[name].Database.Password
+}
+
+// keepSecretRef 配置中原本保存的是环境变量或密钥引用而表单中填写了明文密码时，保存时保留原来的引用
+// 明文密码只用于本次连接，不写入配置文件
+func keepSecretRef(cfg *config.Config, name string, database config.DatabaseConfig) config.DatabaseConfig {
+	saved := savedPassword(cfg, name)
+	if config.IsSecretRef(saved) && !config.IsSecretRef(database.Password) {
+		database.Password = saved
+	}
+	return database
+}
+
+// plaintextPassword 保存后是否会向配置文件写入新的明文密码
+func plaintextPassword(cfg *config.Config, name string, database config.DatabaseConfig) bool {
+	return database.Password != "" && !config.IsSecretRef(database.Password) && database.Password != savedPassword(cfg, name)
+}
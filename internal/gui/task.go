@@ -0,0 +1,28 @@
+package gui
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// runTask 在后台执行耗时操作，期间显示带取消按钮的进度对话框，避免界面卡死
+// 操作完成后关闭对话框并调用done；点击取消时work收到的ctx被取消，且不再调用done
+func runTask(w fyne.Window, title string, work func(ctx context.Context) error, done func(err error)) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := dialog.NewCustom(title, "取消", widget.NewProgressBarInfinite(), w)
+	d.SetOnClosed(cancel)
+	d.Show()
+
+	go func() {
+		err := work(ctx)
+		canceled := ctx.Err() != nil
+		d.Hide()
+		if !canceled {
+			done(err)
+		}
+	}()
+}